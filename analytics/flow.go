@@ -0,0 +1,256 @@
+// Package analytics computes flow metrics such as cycle time, lead time,
+// throughput and work in progress from the history of Jira issues.
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kainhuck/go-jira"
+)
+
+// Config controls which status categories delimit the cycle and lead time of an issue.
+// The zero value uses the default Jira status categories.
+type Config struct {
+	// StartCategories are the status category keys that mark the start of work on an issue.
+	// Default: jira.StatusCategoryInProgress.
+	StartCategories []string
+	// DoneCategories are the status category keys that mark an issue as finished.
+	// Default: jira.StatusCategoryComplete.
+	DoneCategories []string
+	// Now is used to close the interval of the current status of an issue.
+	// Default: time.Now().
+	Now time.Time
+}
+
+func (c Config) withDefaults() Config {
+	if len(c.StartCategories) == 0 {
+		c.StartCategories = []string{jira.StatusCategoryInProgress}
+	}
+	if len(c.DoneCategories) == 0 {
+		c.DoneCategories = []string{jira.StatusCategoryComplete}
+	}
+	if c.Now.IsZero() {
+		c.Now = time.Now()
+	}
+	return c
+}
+
+func (c Config) isStart(category string) bool {
+	return contains(c.StartCategories, category)
+}
+
+func (c Config) isDone(category string) bool {
+	return contains(c.DoneCategories, category)
+}
+
+// StatusCategories maps statuses to the key of their status category.
+// Statuses are looked up by ID first and by name second, because changelog items carry both.
+type StatusCategories struct {
+	byID   map[string]string
+	byName map[string]string
+}
+
+// NewStatusCategories builds the status to category mapping from a list of statuses,
+// e.g. the result of StatusService.GetAllStatuses.
+func NewStatusCategories(statuses []jira.Status) StatusCategories {
+	c := StatusCategories{
+		byID:   make(map[string]string, len(statuses)),
+		byName: make(map[string]string, len(statuses)),
+	}
+	for _, s := range statuses {
+		c.byID[s.ID] = s.StatusCategory.Key
+		c.byName[s.Name] = s.StatusCategory.Key
+	}
+	return c
+}
+
+// LoadStatusCategories fetches all statuses of the Jira instance and builds the status to category mapping.
+func LoadStatusCategories(ctx context.Context, client *jira.Client) (StatusCategories, error) {
+	statuses, _, err := client.Status.GetAllStatuses(ctx)
+	if err != nil {
+		return StatusCategories{}, err
+	}
+	return NewStatusCategories(statuses), nil
+}
+
+// Category returns the status category key of the status with the given ID or name.
+// If the status is unknown, jira.StatusCategoryUndefined is returned.
+func (c StatusCategories) Category(id, name string) string {
+	if key, ok := c.byID[id]; ok && id != "" {
+		return key
+	}
+	if key, ok := c.byName[name]; ok && name != "" {
+		return key
+	}
+	return jira.StatusCategoryUndefined
+}
+
+// StatusPeriod is an interval of time an issue spent in a single status.
+type StatusPeriod struct {
+	Status   string
+	Category string
+	Start    time.Time
+	End      time.Time
+}
+
+// Duration returns the length of the period.
+func (p StatusPeriod) Duration() time.Duration {
+	return p.End.Sub(p.Start)
+}
+
+// IssueFlow holds the flow metrics of a single issue.
+type IssueFlow struct {
+	Key     string
+	Created time.Time
+	// Started is the first time the issue entered a start or done category. Nil if work has not started.
+	Started *time.Time
+	// Completed is the last time the issue entered a done category. Nil if the issue is not done.
+	Completed *time.Time
+	// Periods lists the statuses of the issue in chronological order.
+	Periods []StatusPeriod
+	// TimeInStatus is the total time spent per status name.
+	TimeInStatus map[string]time.Duration
+	// CycleTime is the time between Started and Completed. Zero if the issue is not done.
+	CycleTime time.Duration
+	// LeadTime is the time between Created and Completed. Zero if the issue is not done.
+	LeadTime time.Duration
+}
+
+// IsDone reports whether the issue is currently in a done category.
+func (f *IssueFlow) IsDone() bool {
+	return f.Completed != nil
+}
+
+// inProgressAt reports whether the issue was started but not yet completed at t.
+func (f *IssueFlow) inProgressAt(t time.Time) bool {
+	if f.Started == nil || f.Started.After(t) {
+		return false
+	}
+	return f.Completed == nil || f.Completed.After(t)
+}
+
+type statusChange struct {
+	at               time.Time
+	fromID, fromName string
+	toID, toName     string
+}
+
+// ComputeIssueFlow computes the flow metrics of an issue from its changelog.
+// The issue must have been fetched with the "changelog" expansion and must contain the created and status fields.
+func ComputeIssueFlow(issue *jira.Issue, categories StatusCategories, cfg Config) (*IssueFlow, error) {
+	cfg = cfg.withDefaults()
+	if issue.Fields == nil {
+		return nil, fmt.Errorf("issue %s has no fields", issue.Key)
+	}
+
+	changes, err := statusChanges(issue)
+	if err != nil {
+		return nil, err
+	}
+
+	flow := &IssueFlow{
+		Key:          issue.Key,
+		Created:      time.Time(issue.Fields.Created),
+		TimeInStatus: make(map[string]time.Duration),
+	}
+
+	var currentID, currentName string
+	if len(changes) > 0 {
+		currentID, currentName = changes[0].fromID, changes[0].fromName
+	} else if issue.Fields.Status != nil {
+		currentID, currentName = issue.Fields.Status.ID, issue.Fields.Status.Name
+	}
+
+	since := flow.Created
+	for _, c := range append(changes, statusChange{at: cfg.Now}) {
+		flow.addPeriod(currentID, currentName, categories.Category(currentID, currentName), since, c.at)
+		if c.toID == "" && c.toName == "" {
+			break
+		}
+		currentID, currentName, since = c.toID, c.toName, c.at
+	}
+
+	for i, p := range flow.Periods {
+		if flow.Started == nil && (cfg.isStart(p.Category) || cfg.isDone(p.Category)) {
+			start := p.Start
+			flow.Started = &start
+		}
+		if cfg.isDone(p.Category) && (i == 0 || !cfg.isDone(flow.Periods[i-1].Category)) {
+			done := p.Start
+			flow.Completed = &done
+		}
+	}
+	if n := len(flow.Periods); n > 0 && !cfg.isDone(flow.Periods[n-1].Category) {
+		flow.Completed = nil
+	}
+
+	if flow.Completed != nil {
+		flow.LeadTime = flow.Completed.Sub(flow.Created)
+		flow.CycleTime = flow.Completed.Sub(*flow.Started)
+	}
+
+	return flow, nil
+}
+
+func (f *IssueFlow) addPeriod(id, name, category string, start, end time.Time) {
+	if end.Before(start) {
+		end = start
+	}
+	status := name
+	if status == "" {
+		status = id
+	}
+	f.Periods = append(f.Periods, StatusPeriod{Status: status, Category: category, Start: start, End: end})
+	f.TimeInStatus[status] += end.Sub(start)
+}
+
+// statusChanges returns the status transitions of an issue in chronological order.
+func statusChanges(issue *jira.Issue) ([]statusChange, error) {
+	if issue.Changelog == nil {
+		return nil, nil
+	}
+
+	var changes []statusChange
+	for _, h := range issue.Changelog.Histories {
+		for _, item := range h.Items {
+			if item.Field != "status" {
+				continue
+			}
+			at, err := h.CreatedTime()
+			if err != nil {
+				return nil, fmt.Errorf("issue %s: %w", issue.Key, err)
+			}
+			changes = append(changes, statusChange{
+				at:       at,
+				fromID:   itemValue(item.From),
+				fromName: item.FromString,
+				toID:     itemValue(item.To),
+				toName:   item.ToString,
+			})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].at.Before(changes[j].at)
+	})
+	return changes, nil
+}
+
+func itemValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kainhuck/go-jira"
+)
+
+var testStatuses = []jira.Status{
+	{ID: "1", Name: "Open", StatusCategory: jira.StatusCategory{Key: jira.StatusCategoryToDo}},
+	{ID: "3", Name: "In Progress", StatusCategory: jira.StatusCategory{Key: jira.StatusCategoryInProgress}},
+	{ID: "4", Name: "In Review", StatusCategory: jira.StatusCategory{Key: jira.StatusCategoryInProgress}},
+	{ID: "6", Name: "Closed", StatusCategory: jira.StatusCategory{Key: jira.StatusCategoryComplete}},
+}
+
+func testTime(s string) time.Time {
+	t, err := time.Parse("2006-01-02T15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func statusHistory(at string, fromID, fromName, toID, toName string) jira.ChangelogHistory {
+	return jira.ChangelogHistory{
+		Created: testTime(at).Format("2006-01-02T15:04:05.000-0700"),
+		Items: []jira.ChangelogItems{
+			{Field: "status", From: fromID, FromString: fromName, To: toID, ToString: toName},
+		},
+	}
+}
+
+func testIssue(key, created string, histories ...jira.ChangelogHistory) *jira.Issue {
+	return &jira.Issue{
+		Key: key,
+		Fields: &jira.IssueFields{
+			Created: jira.Time(testTime(created)),
+			Status:  &testStatuses[0],
+		},
+		Changelog: &jira.Changelog{Histories: histories},
+	}
+}
+
+func TestComputeIssueFlow_Done(t *testing.T) {
+	issue := testIssue("TEST-1", "2022-01-03T09:00",
+		statusHistory("2022-01-04T09:00", "1", "Open", "3", "In Progress"),
+		statusHistory("2022-01-05T09:00", "3", "In Progress", "4", "In Review"),
+		statusHistory("2022-01-06T09:00", "4", "In Review", "3", "In Progress"),
+		statusHistory("2022-01-07T09:00", "3", "In Progress", "6", "Closed"),
+	)
+
+	flow, err := ComputeIssueFlow(issue, NewStatusCategories(testStatuses), Config{Now: testTime("2022-01-10T09:00")})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	if !flow.IsDone() {
+		t.Fatal("Expected issue to be done")
+	}
+	if want := testTime("2022-01-04T09:00"); !flow.Started.Equal(want) {
+		t.Errorf("Expected started %v, got %v", want, flow.Started)
+	}
+	if flow.CycleTime != 72*time.Hour {
+		t.Errorf("Expected cycle time 72h, got %v", flow.CycleTime)
+	}
+	if flow.LeadTime != 96*time.Hour {
+		t.Errorf("Expected lead time 96h, got %v", flow.LeadTime)
+	}
+	if got := flow.TimeInStatus["In Progress"]; got != 48*time.Hour {
+		t.Errorf("Expected 48h in progress, got %v", got)
+	}
+	if got := flow.TimeInStatus["Closed"]; got != 72*time.Hour {
+		t.Errorf("Expected 72h closed, got %v", got)
+	}
+	if len(flow.Periods) != 5 {
+		t.Errorf("Expected 5 periods, got %d", len(flow.Periods))
+	}
+}
+
+func TestComputeIssueFlow_Reopened(t *testing.T) {
+	issue := testIssue("TEST-2", "2022-01-03T09:00",
+		statusHistory("2022-01-04T09:00", "1", "Open", "6", "Closed"),
+		statusHistory("2022-01-05T09:00", "6", "Closed", "3", "In Progress"),
+	)
+
+	flow, err := ComputeIssueFlow(issue, NewStatusCategories(testStatuses), Config{Now: testTime("2022-01-10T09:00")})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	if flow.IsDone() {
+		t.Error("Expected reopened issue not to be done")
+	}
+	if flow.CycleTime != 0 || flow.LeadTime != 0 {
+		t.Errorf("Expected no cycle and lead time, got %v and %v", flow.CycleTime, flow.LeadTime)
+	}
+	if flow.Started == nil {
+		t.Error("Expected issue to be started")
+	}
+}
+
+func TestComputeIssueFlow_CustomCategories(t *testing.T) {
+	issue := testIssue("TEST-3", "2022-01-03T09:00",
+		statusHistory("2022-01-04T09:00", "1", "Open", "3", "In Progress"),
+		statusHistory("2022-01-05T09:00", "3", "In Progress", "6", "Closed"),
+	)
+	cfg := Config{
+		StartCategories: []string{jira.StatusCategoryToDo},
+		Now:             testTime("2022-01-10T09:00"),
+	}
+
+	flow, err := ComputeIssueFlow(issue, NewStatusCategories(testStatuses), cfg)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if flow.CycleTime != flow.LeadTime {
+		t.Errorf("Expected cycle time to equal lead time when work starts in To Do, got %v and %v", flow.CycleTime, flow.LeadTime)
+	}
+}
+
+func TestComputeIssueFlow_NoChangelog(t *testing.T) {
+	issue := testIssue("TEST-4", "2022-01-03T09:00")
+	issue.Changelog = nil
+
+	flow, err := ComputeIssueFlow(issue, NewStatusCategories(testStatuses), Config{Now: testTime("2022-01-04T09:00")})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if got := flow.TimeInStatus["Open"]; got != 24*time.Hour {
+		t.Errorf("Expected 24h open, got %v", got)
+	}
+	if flow.Started != nil {
+		t.Error("Expected issue not to be started")
+	}
+}
+
+func TestStatusCategories_Category(t *testing.T) {
+	c := NewStatusCategories(testStatuses)
+
+	if got := c.Category("6", ""); got != jira.StatusCategoryComplete {
+		t.Errorf("Expected %s by ID, got %s", jira.StatusCategoryComplete, got)
+	}
+	if got := c.Category("", "In Review"); got != jira.StatusCategoryInProgress {
+		t.Errorf("Expected %s by name, got %s", jira.StatusCategoryInProgress, got)
+	}
+	if got := c.Category("99", "Unknown"); got != jira.StatusCategoryUndefined {
+		t.Errorf("Expected %s, got %s", jira.StatusCategoryUndefined, got)
+	}
+}
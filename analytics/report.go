@@ -0,0 +1,229 @@
+package analytics
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/kainhuck/go-jira"
+)
+
+// DurationStats summarizes a set of durations.
+// Percentiles use the nearest-rank method.
+type DurationStats struct {
+	Count int
+	Mean  time.Duration
+	P50   time.Duration
+	P70   time.Duration
+	P85   time.Duration
+	P95   time.Duration
+	Max   time.Duration
+}
+
+// NewDurationStats computes the statistics of the given durations.
+func NewDurationStats(durations []time.Duration) DurationStats {
+	if len(durations) == 0 {
+		return DurationStats{}
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	return DurationStats{
+		Count: len(sorted),
+		Mean:  total / time.Duration(len(sorted)),
+		P50:   Percentile(sorted, 50),
+		P70:   Percentile(sorted, 70),
+		P85:   Percentile(sorted, 85),
+		P95:   Percentile(sorted, 95),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// Percentile returns the p-th percentile (0 < p <= 100) of an ascending sorted list of durations
+// using the nearest-rank method.
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// WeekStats holds the throughput and work in progress of one week.
+type WeekStats struct {
+	// Start is the Monday 00:00 the week starts at.
+	Start time.Time
+	// Throughput is the number of issues completed during the week.
+	Throughput int
+	// WIP is the number of issues started but not completed at the end of the week.
+	WIP int
+}
+
+// Report contains the flow metrics of a set of issues.
+type Report struct {
+	Issues    []*IssueFlow
+	CycleTime DurationStats
+	LeadTime  DurationStats
+	Weeks     []WeekStats
+}
+
+// NewReport aggregates the flow metrics of the given issues.
+// Weeks span from the week of the earliest start or completion until the week of cfg.Now.
+func NewReport(flows []*IssueFlow, cfg Config) *Report {
+	cfg = cfg.withDefaults()
+	r := &Report{Issues: flows}
+
+	var cycle, lead []time.Duration
+	var first time.Time
+	for _, f := range flows {
+		if f.IsDone() {
+			cycle = append(cycle, f.CycleTime)
+			lead = append(lead, f.LeadTime)
+		}
+		if f.Started != nil && (first.IsZero() || f.Started.Before(first)) {
+			first = *f.Started
+		}
+	}
+	r.CycleTime = NewDurationStats(cycle)
+	r.LeadTime = NewDurationStats(lead)
+
+	if first.IsZero() {
+		return r
+	}
+	for week := weekStart(first); week.Before(cfg.Now); week = week.AddDate(0, 0, 7) {
+		end := week.AddDate(0, 0, 7)
+		stats := WeekStats{Start: week}
+		for _, f := range flows {
+			if f.Completed != nil && !f.Completed.Before(week) && f.Completed.Before(end) {
+				stats.Throughput++
+			}
+			if f.inProgressAt(end) {
+				stats.WIP++
+			}
+		}
+		r.Weeks = append(r.Weeks, stats)
+	}
+	return r
+}
+
+// weekStart returns the Monday 00:00 of the week t is in, in the location of t.
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	y, m, d := t.AddDate(0, 0, -offset).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// Analyze searches the issues matching jql, including their changelog, and computes their flow metrics.
+func Analyze(ctx context.Context, client *jira.Client, jql string, cfg Config) (*Report, error) {
+	cfg = cfg.withDefaults()
+	categories, err := LoadStatusCategories(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var flows []*IssueFlow
+	options := &jira.SearchOptions{
+		MaxResults: 50,
+		Expand:     "changelog",
+		Fields:     []string{"created", "status"},
+	}
+	err = client.Issue.SearchPages(ctx, jql, options, func(issue jira.Issue) error {
+		flow, err := ComputeIssueFlow(&issue, categories, cfg)
+		if err != nil {
+			return err
+		}
+		flows = append(flows, flow)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReport(flows, cfg), nil
+}
+
+// WriteIssuesCSV writes one row per issue with its dates, cycle and lead time and the time spent
+// in every status. Durations are written in hours.
+func (r *Report) WriteIssuesCSV(w io.Writer) error {
+	statuses := map[string]bool{}
+	for _, f := range r.Issues {
+		for status := range f.TimeInStatus {
+			statuses[status] = true
+		}
+	}
+	statusNames := make([]string, 0, len(statuses))
+	for status := range statuses {
+		statusNames = append(statusNames, status)
+	}
+	sort.Strings(statusNames)
+
+	cw := csv.NewWriter(w)
+	header := []string{"key", "created", "started", "completed", "cycle_time_hours", "lead_time_hours"}
+	for _, status := range statusNames {
+		header = append(header, "time_in_"+status+"_hours")
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, f := range r.Issues {
+		row := []string{f.Key, formatTime(&f.Created), formatTime(f.Started), formatTime(f.Completed), "", ""}
+		if f.IsDone() {
+			row[4] = formatHours(f.CycleTime)
+			row[5] = formatHours(f.LeadTime)
+		}
+		for _, status := range statusNames {
+			row = append(row, formatHours(f.TimeInStatus[status]))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteWeeksCSV writes one row per week with its throughput and work in progress.
+func (r *Report) WriteWeeksCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"week", "throughput", "wip"}); err != nil {
+		return err
+	}
+	for _, week := range r.Weeks {
+		row := []string{week.Start.Format("2006-01-02"), strconv.Itoa(week.Throughput), strconv.Itoa(week.WIP)}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formatHours(d time.Duration) string {
+	return strconv.FormatFloat(d.Hours(), 'f', 2, 64)
+}
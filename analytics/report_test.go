@@ -0,0 +1,147 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kainhuck/go-jira"
+)
+
+func TestNewDurationStats(t *testing.T) {
+	var durations []time.Duration
+	for i := 10; i >= 1; i-- {
+		durations = append(durations, time.Duration(i)*time.Hour)
+	}
+
+	stats := NewDurationStats(durations)
+	if stats.Count != 10 {
+		t.Errorf("Expected count 10, got %d", stats.Count)
+	}
+	if stats.P50 != 5*time.Hour {
+		t.Errorf("Expected P50 5h, got %v", stats.P50)
+	}
+	if stats.P85 != 9*time.Hour {
+		t.Errorf("Expected P85 9h, got %v", stats.P85)
+	}
+	if stats.Max != 10*time.Hour {
+		t.Errorf("Expected max 10h, got %v", stats.Max)
+	}
+	if stats.Mean != 330*time.Minute {
+		t.Errorf("Expected mean 5h30m, got %v", stats.Mean)
+	}
+}
+
+func TestNewReport_Weeks(t *testing.T) {
+	categories := NewStatusCategories(testStatuses)
+	cfg := Config{Now: testTime("2022-01-19T09:00")}
+
+	var flows []*IssueFlow
+	for _, issue := range []*jira.Issue{
+		testIssue("TEST-1", "2022-01-03T09:00",
+			statusHistory("2022-01-04T09:00", "1", "Open", "3", "In Progress"),
+			statusHistory("2022-01-06T09:00", "3", "In Progress", "6", "Closed"),
+		),
+		testIssue("TEST-2", "2022-01-03T09:00",
+			statusHistory("2022-01-05T09:00", "1", "Open", "3", "In Progress"),
+			statusHistory("2022-01-12T09:00", "3", "In Progress", "6", "Closed"),
+		),
+		testIssue("TEST-3", "2022-01-03T09:00",
+			statusHistory("2022-01-11T09:00", "1", "Open", "3", "In Progress"),
+		),
+	} {
+		flow, err := ComputeIssueFlow(issue, categories, cfg)
+		if err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+		flows = append(flows, flow)
+	}
+
+	r := NewReport(flows, cfg)
+	if r.CycleTime.Count != 2 {
+		t.Errorf("Expected 2 completed issues, got %d", r.CycleTime.Count)
+	}
+
+	want := []WeekStats{
+		{Start: testTime("2022-01-03T00:00"), Throughput: 1, WIP: 1},
+		{Start: testTime("2022-01-10T00:00"), Throughput: 1, WIP: 1},
+		{Start: testTime("2022-01-17T00:00"), Throughput: 0, WIP: 1},
+	}
+	if len(r.Weeks) != len(want) {
+		t.Fatalf("Expected %d weeks, got %d", len(want), len(r.Weeks))
+	}
+	for i, w := range want {
+		got := r.Weeks[i]
+		if !got.Start.Equal(w.Start) || got.Throughput != w.Throughput || got.WIP != w.WIP {
+			t.Errorf("Week %d: expected %+v, got %+v", i, w, got)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteWeeksCSV(&buf); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if !strings.HasPrefix(buf.String(), "week,throughput,wip\n2022-01-03,1,1\n") {
+		t.Errorf("Unexpected weeks CSV:\n%s", buf.String())
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/rest/api/2/status", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":"1","name":"Open","statusCategory":{"key":"new"}},
+			{"id":"3","name":"In Progress","statusCategory":{"key":"indeterminate"}},
+			{"id":"6","name":"Closed","statusCategory":{"key":"done"}}]`)
+	})
+	mux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("expand"); got != "changelog" {
+			t.Errorf("Expected changelog expansion, got %q", got)
+		}
+		fmt.Fprint(w, `{"startAt":0,"maxResults":50,"total":1,"issues":[{"key":"TEST-1",
+			"fields":{"created":"2022-01-03T09:00:00.000+0000","status":{"id":"6","name":"Closed"}},
+			"changelog":{"histories":[
+				{"created":"2022-01-04T09:00:00.000+0000","items":[{"field":"status","from":"1","fromString":"Open","to":"3","toString":"In Progress"}]},
+				{"created":"2022-01-05T21:00:00.000+0000","items":[{"field":"status","from":"3","fromString":"In Progress","to":"6","toString":"Closed"}]}
+			]}}]}`)
+	})
+
+	client, err := jira.NewClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := Analyze(context.Background(), client, "project = TEST", Config{Now: testTime("2022-01-10T09:00")})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(r.Issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(r.Issues))
+	}
+	if r.CycleTime.P50 != 36*time.Hour {
+		t.Errorf("Expected cycle time 36h, got %v", r.CycleTime.P50)
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteIssuesCSV(&buf); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected header and 1 row, got %d rows", len(rows))
+	}
+	if rows[1][0] != "TEST-1" || rows[1][4] != "36.00" || rows[1][5] != "60.00" {
+		t.Errorf("Unexpected CSV row: %v", rows[1])
+	}
+}
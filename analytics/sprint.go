@@ -0,0 +1,485 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kainhuck/go-jira"
+)
+
+// Estimation reports how an issue is estimated on a board.
+// It is usually taken from the board configuration, see NewEstimation.
+type Estimation struct {
+	// FieldID is the ID of the estimation field, e.g. "customfield_10002" or "timeoriginalestimate".
+	// If empty, every issue counts as 1.
+	FieldID string
+	// FieldName is the name of the field as it appears in the changelog, e.g. "Story Points".
+	FieldName string
+}
+
+// NewEstimation returns the estimation configured for a board.
+func NewEstimation(config *jira.BoardConfiguration) Estimation {
+	if config.Estimation.Type != "field" {
+		return Estimation{}
+	}
+	return Estimation{
+		FieldID:   config.Estimation.Field.FieldID,
+		FieldName: config.Estimation.Field.DisplayName,
+	}
+}
+
+func (e Estimation) matches(field string) bool {
+	return e.FieldID != "" && (field == e.FieldID || field == e.FieldName)
+}
+
+// current returns the current estimate of an issue.
+// Time based estimates are returned in seconds.
+func (e Estimation) current(issue *jira.Issue) float64 {
+	if e.FieldID == "" {
+		return 1
+	}
+	if issue.Fields == nil {
+		return 0
+	}
+	switch e.FieldID {
+	case "timeoriginalestimate":
+		return float64(issue.Fields.TimeOriginalEstimate)
+	case "timeestimate":
+		return float64(issue.Fields.TimeEstimate)
+	}
+	if v, ok := issue.Fields.Unknowns[e.FieldID]; ok && v != nil {
+		return parseEstimate(fmt.Sprint(v))
+	}
+	return 0
+}
+
+func parseEstimate(s string) float64 {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+// fieldChange is a single change of a field taken from the changelog.
+type fieldChange struct {
+	at         time.Time
+	from, to   string
+	fromString string
+	toString   string
+}
+
+// issueHistory is the changelog of an issue, split by the fields relevant to sprint reports.
+type issueHistory struct {
+	issue    *jira.Issue
+	sprint   []fieldChange
+	estimate []fieldChange
+	status   []fieldChange
+}
+
+func newIssueHistory(issue *jira.Issue, estimation Estimation) (*issueHistory, error) {
+	h := &issueHistory{issue: issue}
+	if issue.Changelog == nil {
+		return h, nil
+	}
+	for _, history := range issue.Changelog.Histories {
+		at, err := history.CreatedTime()
+		if err != nil {
+			return nil, fmt.Errorf("issue %s: %w", issue.Key, err)
+		}
+		for _, item := range history.Items {
+			c := fieldChange{
+				at:         at,
+				from:       itemValue(item.From),
+				to:         itemValue(item.To),
+				fromString: item.FromString,
+				toString:   item.ToString,
+			}
+			switch {
+			case item.Field == "Sprint":
+				h.sprint = append(h.sprint, c)
+			case item.Field == "status":
+				h.status = append(h.status, c)
+			case estimation.matches(item.Field):
+				h.estimate = append(h.estimate, c)
+			}
+		}
+	}
+	for _, changes := range [][]fieldChange{h.sprint, h.estimate, h.status} {
+		sort.SliceStable(changes, func(i, j int) bool { return changes[i].at.Before(changes[j].at) })
+	}
+	return h, nil
+}
+
+// changeAfter returns the first change after t, or nil if the field did not change after t.
+func changeAfter(changes []fieldChange, t time.Time) *fieldChange {
+	for i := range changes {
+		if changes[i].at.After(t) {
+			return &changes[i]
+		}
+	}
+	return nil
+}
+
+// inSprintAt reports whether the issue belonged to the sprint at t.
+// Issues without sprint changes are assumed to have been in the sprint since their creation.
+func (h *issueHistory) inSprintAt(sprintID int, t time.Time) bool {
+	if len(h.sprint) == 0 {
+		return !time.Time(h.issue.Fields.Created).After(t)
+	}
+	if c := changeAfter(h.sprint, t); c != nil {
+		return containsSprint(c.from, sprintID)
+	}
+	return containsSprint(h.sprint[len(h.sprint)-1].to, sprintID)
+}
+
+// containsSprint reports whether a changelog value such as "12, 13" contains the sprint ID.
+func containsSprint(value string, sprintID int) bool {
+	for _, id := range strings.Split(value, ",") {
+		if strings.TrimSpace(id) == strconv.Itoa(sprintID) {
+			return true
+		}
+	}
+	return false
+}
+
+// estimateAt returns the estimate of the issue at t.
+func (h *issueHistory) estimateAt(estimation Estimation, t time.Time) float64 {
+	if estimation.FieldID == "" {
+		return 1
+	}
+	if c := changeAfter(h.estimate, t); c != nil {
+		return changeValue(c.from, c.fromString)
+	}
+	return estimation.current(h.issue)
+}
+
+func changeValue(value, valueString string) float64 {
+	if valueString != "" {
+		return parseEstimate(valueString)
+	}
+	return parseEstimate(value)
+}
+
+// doneAt reports whether the issue was in a done status category at t.
+func (h *issueHistory) doneAt(categories StatusCategories, t time.Time) bool {
+	if c := changeAfter(h.status, t); c != nil {
+		return categories.Category(c.from, c.fromString) == jira.StatusCategoryComplete
+	}
+	if n := len(h.status); n > 0 {
+		return categories.Category(h.status[n-1].to, h.status[n-1].toString) == jira.StatusCategoryComplete
+	}
+	if status := h.issue.Fields.Status; status != nil {
+		return categories.Category(status.ID, status.Name) == jira.StatusCategoryComplete
+	}
+	return false
+}
+
+// SprintReport compares the work committed to a sprint with the work completed in it.
+// Estimates are in the unit of the estimation field; time based estimates are in seconds.
+type SprintReport struct {
+	Sprint jira.Sprint
+	// Committed is the estimate of all issues in the sprint when it started.
+	Committed float64
+	// Completed is the estimate of all issues in the sprint that were done when it ended.
+	Completed float64
+	// Added is the estimate of the issues added after the sprint started.
+	Added float64
+	// Removed is the estimate of the issues removed after the sprint started.
+	Removed float64
+
+	CommittedIssues []string
+	CompletedIssues []string
+	AddedIssues     []string
+	RemovedIssues   []string
+}
+
+// sprintPeriod returns the start and end of a sprint. The end of an active sprint is now.
+func sprintPeriod(sprint jira.Sprint, now time.Time) (time.Time, time.Time, error) {
+	if sprint.StartDate == nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("sprint %d has not been started", sprint.ID)
+	}
+	end := now
+	if sprint.CompleteDate != nil {
+		end = *sprint.CompleteDate
+	}
+	return *sprint.StartDate, end, nil
+}
+
+// NewSprintReport computes the report of a sprint from the issues that were part of it at any time.
+// The issues must have been fetched with the "changelog" expansion.
+func NewSprintReport(sprint jira.Sprint, issues []jira.Issue, estimation Estimation, categories StatusCategories, now time.Time) (*SprintReport, error) {
+	start, end, err := sprintPeriod(sprint, now)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &SprintReport{Sprint: sprint}
+	for i := range issues {
+		h, err := newIssueHistory(&issues[i], estimation)
+		if err != nil {
+			return nil, err
+		}
+		key := issues[i].Key
+
+		atStart := h.inSprintAt(sprint.ID, start)
+		atEnd := h.inSprintAt(sprint.ID, end)
+		if atStart {
+			r.Committed += h.estimateAt(estimation, start)
+			r.CommittedIssues = append(r.CommittedIssues, key)
+		}
+		if atEnd && h.doneAt(categories, end) {
+			r.Completed += h.estimateAt(estimation, end)
+			r.CompletedIssues = append(r.CompletedIssues, key)
+		}
+		for _, c := range h.sprint {
+			if c.at.Before(start) || c.at.After(end) {
+				continue
+			}
+			if !containsSprint(c.from, sprint.ID) && containsSprint(c.to, sprint.ID) {
+				r.Added += h.estimateAt(estimation, c.at)
+				r.AddedIssues = append(r.AddedIssues, key)
+			}
+			if containsSprint(c.from, sprint.ID) && !containsSprint(c.to, sprint.ID) {
+				r.Removed += h.estimateAt(estimation, c.at)
+				r.RemovedIssues = append(r.RemovedIssues, key)
+			}
+		}
+	}
+	return r, nil
+}
+
+// VelocityOptions specifies the optional parameters to Velocity.
+type VelocityOptions struct {
+	// Sprints is the number of most recently closed sprints to report on. Default: 7.
+	Sprints int
+}
+
+// VelocityReport holds the sprint reports of the most recently closed sprints of a board.
+type VelocityReport struct {
+	BoardID    int
+	Estimation Estimation
+	// Sprints are ordered from oldest to newest.
+	Sprints []SprintReport
+}
+
+// Average returns the average completed estimate per sprint.
+func (v *VelocityReport) Average() float64 {
+	if len(v.Sprints) == 0 {
+		return 0
+	}
+	var total float64
+	for _, s := range v.Sprints {
+		total += s.Completed
+	}
+	return total / float64(len(v.Sprints))
+}
+
+// Velocity computes committed vs. completed work for the most recently closed sprints of a board.
+// The estimation field is resolved from the board configuration.
+func Velocity(ctx context.Context, client *jira.Client, boardID int, options *VelocityOptions) (*VelocityReport, error) {
+	count := 7
+	if options != nil && options.Sprints > 0 {
+		count = options.Sprints
+	}
+
+	estimation, categories, err := loadBoardContext(ctx, client, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	sprints, err := closedSprints(ctx, client, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if len(sprints) > count {
+		sprints = sprints[len(sprints)-count:]
+	}
+
+	report := &VelocityReport{BoardID: boardID, Estimation: estimation}
+	for _, sprint := range sprints {
+		issues, err := sprintIssues(ctx, client, boardID, sprint.ID, estimation)
+		if err != nil {
+			return nil, err
+		}
+		r, err := NewSprintReport(sprint, issues, estimation, categories, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		report.Sprints = append(report.Sprints, *r)
+	}
+	return report, nil
+}
+
+// BurndownPoint is the state of a sprint at the end of one day.
+type BurndownPoint struct {
+	Date time.Time
+	// Remaining is the estimate of the issues in the sprint that are not done.
+	Remaining float64
+	// Scope is the estimate of all issues in the sprint.
+	Scope float64
+}
+
+// Burndown holds the daily remaining work of a sprint.
+type Burndown struct {
+	Sprint     jira.Sprint
+	Estimation Estimation
+	Points     []BurndownPoint
+}
+
+// NewBurndown computes the remaining work of a sprint at its start and at the end of every following day.
+// The issues must have been fetched with the "changelog" expansion.
+func NewBurndown(sprint jira.Sprint, issues []jira.Issue, estimation Estimation, categories StatusCategories, now time.Time) (*Burndown, error) {
+	start, end, err := sprintPeriod(sprint, now)
+	if err != nil {
+		return nil, err
+	}
+
+	histories := make([]*issueHistory, 0, len(issues))
+	for i := range issues {
+		h, err := newIssueHistory(&issues[i], estimation)
+		if err != nil {
+			return nil, err
+		}
+		histories = append(histories, h)
+	}
+
+	b := &Burndown{Sprint: sprint, Estimation: estimation}
+	point := func(t time.Time) BurndownPoint {
+		p := BurndownPoint{Date: t}
+		for _, h := range histories {
+			if !h.inSprintAt(sprint.ID, t) {
+				continue
+			}
+			estimate := h.estimateAt(estimation, t)
+			p.Scope += estimate
+			if !h.doneAt(categories, t) {
+				p.Remaining += estimate
+			}
+		}
+		return p
+	}
+
+	b.Points = append(b.Points, point(start))
+	y, m, d := start.Date()
+	for day := time.Date(y, m, d+1, 0, 0, 0, 0, start.Location()); day.Before(end); day = day.AddDate(0, 0, 1) {
+		b.Points = append(b.Points, point(day))
+	}
+	b.Points = append(b.Points, point(end))
+	return b, nil
+}
+
+// SprintBurndown computes the burndown of a sprint of a board.
+// The estimation field is resolved from the board configuration.
+func SprintBurndown(ctx context.Context, client *jira.Client, boardID, sprintID int) (*Burndown, error) {
+	estimation, categories, err := loadBoardContext(ctx, client, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	sprints, err := allSprints(ctx, client, boardID, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, sprint := range sprints {
+		if sprint.ID != sprintID {
+			continue
+		}
+		issues, err := sprintIssues(ctx, client, boardID, sprintID, estimation)
+		if err != nil {
+			return nil, err
+		}
+		return NewBurndown(sprint, issues, estimation, categories, time.Now())
+	}
+	return nil, fmt.Errorf("sprint %d not found on board %d", sprintID, boardID)
+}
+
+func loadBoardContext(ctx context.Context, client *jira.Client, boardID int) (Estimation, StatusCategories, error) {
+	config, _, err := client.Board.GetBoardConfiguration(ctx, boardID)
+	if err != nil {
+		return Estimation{}, StatusCategories{}, err
+	}
+	categories, err := LoadStatusCategories(ctx, client)
+	if err != nil {
+		return Estimation{}, StatusCategories{}, err
+	}
+	return NewEstimation(config), categories, nil
+}
+
+func closedSprints(ctx context.Context, client *jira.Client, boardID int) ([]jira.Sprint, error) {
+	sprints, err := allSprints(ctx, client, boardID, "closed")
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(sprints, func(i, j int) bool {
+		if sprints[i].CompleteDate == nil || sprints[j].CompleteDate == nil {
+			return sprints[i].ID < sprints[j].ID
+		}
+		return sprints[i].CompleteDate.Before(*sprints[j].CompleteDate)
+	})
+	return sprints, nil
+}
+
+func allSprints(ctx context.Context, client *jira.Client, boardID int, state string) ([]jira.Sprint, error) {
+	options := &jira.GetAllSprintsOptions{State: state}
+	var sprints []jira.Sprint
+	for {
+		list, _, err := client.Board.GetAllSprints(ctx, boardID, options)
+		if err != nil {
+			return nil, err
+		}
+		sprints = append(sprints, list.Values...)
+		if list.IsLast || len(list.Values) == 0 {
+			return sprints, nil
+		}
+		options.StartAt += len(list.Values)
+	}
+}
+
+// sprintIssues returns all issues that are in the sprint or were removed from it after it started.
+// JQL cannot find the removed issues, so their keys are taken from the sprint report of the board.
+func sprintIssues(ctx context.Context, client *jira.Client, boardID, sprintID int, estimation Estimation) ([]jira.Issue, error) {
+	report, _, err := client.Board.GetSprintReport(ctx, boardID, sprintID)
+	if err != nil {
+		return nil, err
+	}
+	queries := []string{fmt.Sprintf("sprint = %d", sprintID)}
+	for start := 0; start < len(report.PuntedIssues); start += 50 {
+		end := start + 50
+		if end > len(report.PuntedIssues) {
+			end = len(report.PuntedIssues)
+		}
+		keys := make([]string, 0, end-start)
+		for _, issue := range report.PuntedIssues[start:end] {
+			keys = append(keys, issue.Key)
+		}
+		queries = append(queries, fmt.Sprintf("key in (%s)", strings.Join(keys, ", ")))
+	}
+
+	fields := []string{"created", "status"}
+	if estimation.FieldID != "" {
+		fields = append(fields, estimation.FieldID)
+	}
+	options := &jira.SearchOptions{MaxResults: 50, Expand: "changelog", Fields: fields}
+
+	var issues []jira.Issue
+	seen := make(map[string]bool)
+	for _, jql := range queries {
+		err := client.Issue.SearchPages(ctx, jql, options, func(issue jira.Issue) error {
+			// Issues removed and added back are in the sprint again
+			if !seen[issue.Key] {
+				seen[issue.Key] = true
+				issues = append(issues, issue)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return issues, nil
+}
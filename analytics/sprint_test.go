@@ -0,0 +1,179 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kainhuck/go-jira"
+	"github.com/trivago/tgo/tcontainer"
+)
+
+var testEstimation = Estimation{FieldID: "customfield_10002", FieldName: "Story Points"}
+
+func fieldHistory(at, field string, from, to interface{}, fromString, toString string) jira.ChangelogHistory {
+	return jira.ChangelogHistory{
+		Created: testTime(at).Format("2006-01-02T15:04:05.000-0700"),
+		Items: []jira.ChangelogItems{
+			{Field: field, From: from, FromString: fromString, To: to, ToString: toString},
+		},
+	}
+}
+
+func testSprintIssue(key string, points float64, status *jira.Status, histories ...jira.ChangelogHistory) jira.Issue {
+	return jira.Issue{
+		Key: key,
+		Fields: &jira.IssueFields{
+			Created:  jira.Time(testTime("2022-01-01T09:00")),
+			Status:   status,
+			Unknowns: tcontainer.MarshalMap{"customfield_10002": points},
+		},
+		Changelog: &jira.Changelog{Histories: histories},
+	}
+}
+
+func testSprint() jira.Sprint {
+	start := testTime("2022-01-03T09:00")
+	end := testTime("2022-01-07T17:00")
+	return jira.Sprint{ID: 12, Name: "Sprint 1", StartDate: &start, CompleteDate: &end, State: "closed"}
+}
+
+func testSprintIssues() []jira.Issue {
+	return []jira.Issue{
+		// committed and completed, re-estimated during the sprint
+		testSprintIssue("TEST-1", 5, &testStatuses[3],
+			fieldHistory("2022-01-04T10:00", "Story Points", nil, nil, "3", "5"),
+			statusHistory("2022-01-05T10:00", "1", "Open", "6", "Closed"),
+		),
+		// committed, not completed
+		testSprintIssue("TEST-2", 8, &testStatuses[1]),
+		// added mid-sprint and completed
+		testSprintIssue("TEST-3", 2, &testStatuses[3],
+			fieldHistory("2022-01-04T12:00", "Sprint", "", "12", "", "Sprint 1"),
+			statusHistory("2022-01-06T12:00", "1", "Open", "6", "Closed"),
+		),
+		// committed, then moved to the next sprint
+		testSprintIssue("TEST-4", 1, &testStatuses[0],
+			fieldHistory("2022-01-02T12:00", "Sprint", "", "12", "", "Sprint 1"),
+			fieldHistory("2022-01-05T12:00", "Sprint", "12", "13", "Sprint 1", "Sprint 2"),
+		),
+	}
+}
+
+func TestNewSprintReport(t *testing.T) {
+	r, err := NewSprintReport(testSprint(), testSprintIssues(), testEstimation, NewStatusCategories(testStatuses), time.Now())
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	if r.Committed != 12 {
+		t.Errorf("Expected 12 committed points, got %v (%v)", r.Committed, r.CommittedIssues)
+	}
+	if r.Completed != 7 {
+		t.Errorf("Expected 7 completed points, got %v (%v)", r.Completed, r.CompletedIssues)
+	}
+	if r.Added != 2 || len(r.AddedIssues) != 1 || r.AddedIssues[0] != "TEST-3" {
+		t.Errorf("Expected TEST-3 to be added with 2 points, got %v (%v)", r.Added, r.AddedIssues)
+	}
+	if r.Removed != 1 || len(r.RemovedIssues) != 1 || r.RemovedIssues[0] != "TEST-4" {
+		t.Errorf("Expected TEST-4 to be removed with 1 point, got %v (%v)", r.Removed, r.RemovedIssues)
+	}
+}
+
+func TestNewBurndown(t *testing.T) {
+	b, err := NewBurndown(testSprint(), testSprintIssues(), testEstimation, NewStatusCategories(testStatuses), time.Now())
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	want := []BurndownPoint{
+		{Date: testTime("2022-01-03T09:00"), Remaining: 12, Scope: 12},
+		{Date: testTime("2022-01-04T00:00"), Remaining: 12, Scope: 12},
+		{Date: testTime("2022-01-05T00:00"), Remaining: 16, Scope: 16},
+		{Date: testTime("2022-01-06T00:00"), Remaining: 10, Scope: 15},
+		{Date: testTime("2022-01-07T00:00"), Remaining: 8, Scope: 15},
+		{Date: testTime("2022-01-07T17:00"), Remaining: 8, Scope: 15},
+	}
+	if len(b.Points) != len(want) {
+		t.Fatalf("Expected %d points, got %d: %+v", len(want), len(b.Points), b.Points)
+	}
+	for i, w := range want {
+		got := b.Points[i]
+		if !got.Date.Equal(w.Date) || got.Remaining != w.Remaining || got.Scope != w.Scope {
+			t.Errorf("Point %d: expected %+v, got %+v", i, w, got)
+		}
+	}
+}
+
+func TestNewSprintReport_NotStarted(t *testing.T) {
+	_, err := NewSprintReport(jira.Sprint{ID: 1}, nil, testEstimation, StatusCategories{}, time.Now())
+	if err == nil {
+		t.Error("Expected an error for a sprint that has not been started")
+	}
+}
+
+func TestVelocity(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/rest/agile/1.0/board/1/configuration", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"estimation":{"type":"field","field":{"fieldId":"customfield_10002","displayName":"Story Points"}}}`)
+	})
+	mux.HandleFunc("/rest/api/2/status", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":"1","name":"Open","statusCategory":{"key":"new"}},{"id":"6","name":"Closed","statusCategory":{"key":"done"}}]`)
+	})
+	mux.HandleFunc("/rest/agile/1.0/board/1/sprint", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("state"); got != "closed" {
+			t.Errorf("Expected closed sprints to be requested, got %q", got)
+		}
+		fmt.Fprint(w, `{"isLast":true,"values":[
+			{"id":12,"name":"Sprint 1","state":"closed","startDate":"2022-01-03T09:00:00.000Z","completeDate":"2022-01-07T17:00:00.000Z"},
+			{"id":13,"name":"Sprint 2","state":"closed","startDate":"2022-01-10T09:00:00.000Z","completeDate":"2022-01-14T17:00:00.000Z"}]}`)
+	})
+	mux.HandleFunc("/rest/greenhopper/1.0/rapid/charts/sprintreport", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.RawQuery; got != "rapidViewId=1&sprintId=13" {
+			t.Errorf("Unexpected sprint report query %q", got)
+		}
+		fmt.Fprint(w, `{"contents":{"completedIssues":[{"id":10001,"key":"TEST-1"}],"puntedIssues":[{"id":10002,"key":"TEST-2"}]}}`)
+	})
+	mux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		switch jql := r.URL.Query().Get("jql"); jql {
+		case "sprint = 13":
+			fmt.Fprint(w, `{"startAt":0,"maxResults":50,"total":1,"issues":[{"key":"TEST-1",
+				"fields":{"created":"2022-01-01T09:00:00.000+0000","status":{"id":"6","name":"Closed"},"customfield_10002":3}}]}`)
+		case "key in (TEST-2)":
+			fmt.Fprint(w, `{"startAt":0,"maxResults":50,"total":1,"issues":[{"key":"TEST-2",
+				"fields":{"created":"2022-01-01T09:00:00.000+0000","status":{"id":"1","name":"Open"},"customfield_10002":5},
+				"changelog":{"histories":[{"created":"2022-01-11T10:00:00.000+0000",
+					"items":[{"field":"Sprint","from":"13","fromString":"Sprint 2","to":"","toString":""}]}]}}]}`)
+		default:
+			t.Errorf("Unexpected JQL %q", jql)
+		}
+	})
+
+	client, err := jira.NewClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := Velocity(context.Background(), client, 1, &VelocityOptions{Sprints: 1})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(v.Sprints) != 1 || v.Sprints[0].Sprint.ID != 13 {
+		t.Fatalf("Expected the last closed sprint, got %+v", v.Sprints)
+	}
+	if v.Estimation != testEstimation {
+		t.Errorf("Expected estimation %+v, got %+v", testEstimation, v.Estimation)
+	}
+	if v.Average() != 3 {
+		t.Errorf("Expected average velocity 3, got %v", v.Average())
+	}
+	if r := v.Sprints[0]; r.Committed != 8 || r.Removed != 5 || len(r.RemovedIssues) != 1 || r.RemovedIssues[0] != "TEST-2" {
+		t.Errorf("Expected TEST-2 to be committed and removed, got %+v", r)
+	}
+}
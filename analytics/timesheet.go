@@ -0,0 +1,206 @@
+package analytics
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/kainhuck/go-jira"
+)
+
+// TimesheetEntry is the time one user logged on one issue on one day.
+type TimesheetEntry struct {
+	// Day is the midnight the day starts at, in the location of the timesheet.
+	Day     time.Time
+	User    string
+	Project string
+	Issue   string
+	Spent   time.Duration
+}
+
+type timesheetKey struct {
+	day                  time.Time
+	user, project, issue string
+}
+
+// Timesheet aggregates worklogs per user, project, issue and day within [From, To).
+type Timesheet struct {
+	From    time.Time
+	To      time.Time
+	Entries []TimesheetEntry
+
+	index map[timesheetKey]int
+}
+
+// NewTimesheet returns an empty timesheet for worklogs started within [from, to).
+// Days are computed in the location of from.
+func NewTimesheet(from, to time.Time) *Timesheet {
+	return &Timesheet{From: from, To: to, index: make(map[timesheetKey]int)}
+}
+
+// Add adds a worklog record of the issue to the timesheet.
+// Records started outside of the range of the timesheet are ignored.
+func (t *Timesheet) Add(issue *jira.Issue, record jira.WorklogRecord) {
+	if record.Started == nil {
+		return
+	}
+	started := time.Time(*record.Started)
+	if started.Before(t.From) || !started.Before(t.To) {
+		return
+	}
+
+	y, m, d := started.In(t.From.Location()).Date()
+	key := timesheetKey{
+		day:     time.Date(y, m, d, 0, 0, 0, 0, t.From.Location()),
+		user:    worklogUser(record.Author),
+		project: issueProject(issue),
+		issue:   issue.Key,
+	}
+	spent := time.Duration(record.TimeSpentSeconds) * time.Second
+
+	if t.index == nil {
+		t.index = make(map[timesheetKey]int)
+	}
+	if i, ok := t.index[key]; ok {
+		t.Entries[i].Spent += spent
+		return
+	}
+	t.index[key] = len(t.Entries)
+	t.Entries = append(t.Entries, TimesheetEntry{Day: key.day, User: key.user, Project: key.project, Issue: key.issue, Spent: spent})
+}
+
+// worklogUser identifies the author of a worklog by user name, falling back to the account ID on Jira Cloud.
+func worklogUser(u *jira.User) string {
+	switch {
+	case u == nil:
+		return ""
+	case u.Name != "":
+		return u.Name
+	case u.AccountID != "":
+		return u.AccountID
+	}
+	return u.DisplayName
+}
+
+func issueProject(issue *jira.Issue) string {
+	if issue.Fields == nil {
+		return ""
+	}
+	return issue.Fields.Project.Key
+}
+
+// Total returns the total time logged.
+func (t *Timesheet) Total() time.Duration {
+	var total time.Duration
+	for _, e := range t.Entries {
+		total += e.Spent
+	}
+	return total
+}
+
+// TotalBy sums the time logged per key returned by f, e.g. per user.
+func (t *Timesheet) TotalBy(f func(TimesheetEntry) string) map[string]time.Duration {
+	totals := make(map[string]time.Duration)
+	for _, e := range t.Entries {
+		totals[f(e)] += e.Spent
+	}
+	return totals
+}
+
+// ByUser sums the time logged per user.
+func (t *Timesheet) ByUser() map[string]time.Duration {
+	return t.TotalBy(func(e TimesheetEntry) string { return e.User })
+}
+
+// ByProject sums the time logged per project key.
+func (t *Timesheet) ByProject() map[string]time.Duration {
+	return t.TotalBy(func(e TimesheetEntry) string { return e.Project })
+}
+
+// ByDay sums the time logged per day, formatted as 2006-01-02.
+func (t *Timesheet) ByDay() map[string]time.Duration {
+	return t.TotalBy(func(e TimesheetEntry) string { return e.Day.Format("2006-01-02") })
+}
+
+// WriteCSV writes one row per day, user, project and issue, sorted in that order. Time is written in hours.
+func (t *Timesheet) WriteCSV(w io.Writer) error {
+	entries := make([]TimesheetEntry, len(t.Entries))
+	copy(entries, t.Entries)
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if !a.Day.Equal(b.Day) {
+			return a.Day.Before(b.Day)
+		}
+		if a.User != b.User {
+			return a.User < b.User
+		}
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		return a.Issue < b.Issue
+	})
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"day", "user", "project", "issue", "hours"}); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		row := []string{e.Day.Format("2006-01-02"), e.User, e.Project, e.Issue, formatHours(e.Spent)}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// BuildTimesheet searches the issues matching jql with worklogs within [from, to) and aggregates their worklogs.
+// If jql is empty, all issues with worklogs in the range are included.
+func BuildTimesheet(ctx context.Context, client *jira.Client, jql string, from, to time.Time) (*Timesheet, error) {
+	t := NewTimesheet(from, to)
+
+	// worklogDate only has day precision, the exact range is applied by Timesheet.Add
+	query := fmt.Sprintf(`worklogDate >= "%s" AND worklogDate <= "%s"`, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if jql != "" {
+		query = fmt.Sprintf("(%s) AND %s", jql, query)
+	}
+
+	options := &jira.SearchOptions{MaxResults: 50, Fields: []string{"project"}}
+	err := client.Issue.SearchPages(ctx, query, options, func(issue jira.Issue) error {
+		return worklogPages(ctx, client, issue.Key, from, func(record jira.WorklogRecord) error {
+			t.Add(&issue, record)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// worklogPages calls f for every worklog of the issue started after the given time.
+func worklogPages(ctx context.Context, client *jira.Client, issueKey string, startedAfter time.Time, f func(jira.WorklogRecord) error) error {
+	opts := &jira.GetWorklogsQueryOptions{
+		MaxResults:   1000,
+		StartedAfter: startedAfter.UnixNano() / int64(time.Millisecond),
+	}
+	for {
+		worklog, _, err := client.Issue.GetWorklogs(ctx, issueKey, jira.WithQueryOptions(opts))
+		if err != nil {
+			return err
+		}
+		for _, record := range worklog.Worklogs {
+			if err := f(record); err != nil {
+				return err
+			}
+		}
+
+		opts.StartAt += int64(len(worklog.Worklogs))
+		if len(worklog.Worklogs) == 0 || opts.StartAt >= int64(worklog.Total) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kainhuck/go-jira"
+)
+
+func testWorklog(user, started string, seconds int) jira.WorklogRecord {
+	t := jira.Time(testTime(started))
+	return jira.WorklogRecord{Author: &jira.User{Name: user}, Started: &t, TimeSpentSeconds: seconds}
+}
+
+func TestTimesheet(t *testing.T) {
+	ts := NewTimesheet(testTime("2022-01-03T00:00"), testTime("2022-01-05T00:00"))
+	a := &jira.Issue{Key: "A-1", Fields: &jira.IssueFields{Project: jira.Project{Key: "A"}}}
+	b := &jira.Issue{Key: "B-1", Fields: &jira.IssueFields{Project: jira.Project{Key: "B"}}}
+
+	ts.Add(a, testWorklog("alice", "2022-01-03T09:00", 3600))
+	ts.Add(a, testWorklog("alice", "2022-01-03T14:00", 1800))
+	ts.Add(b, testWorklog("alice", "2022-01-04T09:00", 7200))
+	ts.Add(b, testWorklog("bob", "2022-01-04T10:00", 3600))
+	// outside of the range
+	ts.Add(a, testWorklog("bob", "2022-01-05T10:00", 3600))
+
+	if len(ts.Entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %+v", ts.Entries)
+	}
+	if ts.Total() != 4*time.Hour+30*time.Minute {
+		t.Errorf("Expected 4h30m in total, got %v", ts.Total())
+	}
+	if got := ts.ByUser(); got["alice"] != 3*time.Hour+30*time.Minute || got["bob"] != time.Hour {
+		t.Errorf("Unexpected totals per user %v", got)
+	}
+	if got := ts.ByProject(); got["A"] != 90*time.Minute || got["B"] != 3*time.Hour {
+		t.Errorf("Unexpected totals per project %v", got)
+	}
+	if got := ts.ByDay(); got["2022-01-03"] != 90*time.Minute || got["2022-01-04"] != 3*time.Hour {
+		t.Errorf("Unexpected totals per day %v", got)
+	}
+
+	var buf bytes.Buffer
+	if err := ts.WriteCSV(&buf); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	want := "day,user,project,issue,hours\n" +
+		"2022-01-03,alice,A,A-1,1.50\n" +
+		"2022-01-04,alice,B,B-1,2.00\n" +
+		"2022-01-04,bob,B,B-1,1.00\n"
+	if buf.String() != want {
+		t.Errorf("Expected CSV\n%s\ngot\n%s", want, buf.String())
+	}
+}
+
+func TestBuildTimesheet(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		want := `(project = A) AND worklogDate >= "2022-01-03" AND worklogDate <= "2022-01-10"`
+		if jql := r.URL.Query().Get("jql"); jql != want {
+			t.Errorf("Expected JQL %q, got %q", want, jql)
+		}
+		fmt.Fprint(w, `{"startAt":0,"maxResults":50,"total":1,"issues":[{"key":"A-1","fields":{"project":{"key":"A"}}}]}`)
+	})
+	mux.HandleFunc("/rest/api/2/issue/A-1/worklog", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"startAt":0,"maxResults":1000,"total":2,"worklogs":[
+			{"author":{"name":"alice"},"started":"2022-01-04T09:00:00.000+0000","timeSpentSeconds":3600},
+			{"author":{"name":"alice"},"started":"2022-01-11T09:00:00.000+0000","timeSpentSeconds":3600}]}`)
+	})
+
+	client, err := jira.NewClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts, err := BuildTimesheet(context.Background(), client, "project = A", testTime("2022-01-03T00:00"), testTime("2022-01-10T00:00"))
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if ts.Total() != time.Hour || len(ts.Entries) != 1 || ts.Entries[0].Project != "A" {
+		t.Errorf("Expected one hour on A-1, got %+v", ts.Entries)
+	}
+}
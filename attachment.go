@@ -0,0 +1,616 @@
+package jira
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// AttachmentMeta represents the attachment settings of the Jira instance.
+type AttachmentMeta struct {
+	Enabled bool `json:"enabled" structs:"enabled"`
+	// UploadLimit is the maximum size of a single attachment in bytes.
+	UploadLimit int64 `json:"uploadLimit" structs:"uploadLimit"`
+}
+
+// GetAttachmentMeta returns the attachment settings of the Jira instance.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/attachment-getAttachmentMeta
+func (s *IssueService) GetAttachmentMeta(ctx context.Context) (*AttachmentMeta, *Response, error) {
+	apiEndpoint := "rest/api/2/attachment/meta"
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	meta := new(AttachmentMeta)
+	resp, err := s.client.Do(req, meta)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return meta, resp, nil
+}
+
+// AttachmentFile is a file to upload with IssueService.UploadAttachments.
+type AttachmentFile struct {
+	Name   string
+	Reader io.Reader
+	// Size is the size of the file in bytes, or 0 if unknown. It is used for size checks and progress reporting.
+	Size int64
+	// ContentType is detected from the file name or, failing that, from the content if empty.
+	ContentType string
+}
+
+// OpenAttachmentFile opens the file at path for uploading. The caller must close the returned file.
+func OpenAttachmentFile(path string) (AttachmentFile, *os.File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return AttachmentFile{}, nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return AttachmentFile{}, nil, err
+	}
+	return AttachmentFile{Name: filepath.Base(path), Reader: f, Size: info.Size()}, f, nil
+}
+
+// UploadOptions specifies the optional parameters to IssueService.UploadAttachments
+type UploadOptions struct {
+	// Progress is called while uploading with the number of bytes of file content sent so far
+	// and the total size of all files, which is 0 if the size of any file is unknown.
+	Progress func(sent, total int64)
+	// CheckSize fetches the attachment settings of the instance before uploading and fails early
+	// if attachments are disabled or a file exceeds the upload limit.
+	CheckSize bool
+}
+
+// UploadAttachments uploads one or more files as attachments to issueID in a single request.
+// The files are streamed, so they are never held in memory.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/issue/{issueIdOrKey}/attachments-addAttachment
+func (s *IssueService) UploadAttachments(ctx context.Context, issueID string, files []AttachmentFile, options *UploadOptions) ([]Attachment, *Response, error) {
+	opts := UploadOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	var limit int64
+	if opts.CheckSize {
+		meta, resp, err := s.GetAttachmentMeta(ctx)
+		if err != nil {
+			return nil, resp, err
+		}
+		if !meta.Enabled {
+			return nil, resp, fmt.Errorf("attachments are disabled")
+		}
+		limit = meta.UploadLimit
+		for _, f := range files {
+			if limit > 0 && f.Size > limit {
+				return nil, resp, fmt.Errorf("attachment %q has %d bytes, which exceeds the upload limit of %d bytes", f.Name, f.Size, limit)
+			}
+		}
+	}
+
+	var total int64
+	for _, f := range files {
+		if f.Size <= 0 {
+			total = 0
+			break
+		}
+		total += f.Size
+	}
+
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeAttachments(writer, files, limit, total, opts.Progress))
+	}()
+
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/attachments", issueID)
+	req, err := s.client.NewRawRequest(ctx, http.MethodPost, apiEndpoint, pr)
+	if err != nil {
+		pr.Close()
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("X-Atlassian-Token", "nocheck")
+
+	// The response is a JSON array, as multiple attachments can be posted
+	var attachments []Attachment
+	resp, err := s.client.Do(req, &attachments)
+	pr.Close()
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return attachments, resp, nil
+}
+
+// writeAttachments writes the files as multipart form and closes the form
+func writeAttachments(writer *multipart.Writer, files []AttachmentFile, limit, total int64, progress func(sent, total int64)) error {
+	var sent int64
+	for _, f := range files {
+		r := bufio.NewReader(emptyReaderIfNil(f.Reader))
+		contentType := f.ContentType
+		if contentType == "" {
+			contentType = detectContentType(f.Name, r)
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, escapeQuotes(f.Name)))
+		header.Set("Content-Type", contentType)
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return err
+		}
+
+		w := &progressWriter{w: part, sent: sent, total: total, progress: progress}
+		var src io.Reader = r
+		if limit > 0 {
+			src = io.LimitReader(r, limit+1)
+		}
+		n, err := io.Copy(w, src)
+		if err != nil {
+			return err
+		}
+		if limit > 0 && n > limit {
+			return fmt.Errorf("attachment %q exceeds the upload limit of %d bytes", f.Name, limit)
+		}
+		sent = w.sent
+	}
+	return writer.Close()
+}
+
+func emptyReaderIfNil(r io.Reader) io.Reader {
+	if r == nil {
+		return strings.NewReader("")
+	}
+	return r
+}
+
+// detectContentType returns the MIME type for the file extension, falling back to sniffing the content
+func detectContentType(name string, r *bufio.Reader) string {
+	if t := mime.TypeByExtension(filepath.Ext(name)); t != "" {
+		return t
+	}
+	head, _ := r.Peek(512)
+	return http.DetectContentType(head)
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+// progressWriter reports the number of bytes written through it
+type progressWriter struct {
+	w        io.Writer
+	sent     int64
+	total    int64
+	progress func(sent, total int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.sent += int64(n)
+	if p.progress != nil && n > 0 {
+		p.progress(p.sent, p.total)
+	}
+	return n, err
+}
+
+// DownloadOptions specifies the optional parameters to IssueService.DownloadAttachmentTo
+type DownloadOptions struct {
+	// Offset resumes a download by skipping the first Offset bytes of the attachment.
+	// The remaining bytes are requested with a Range header.
+	Offset int64
+	// Hash computes a checksum of the downloaded content, e.g. sha256.New().
+	// When resuming, the hash must already contain the first Offset bytes.
+	Hash hash.Hash
+	// Progress is called while downloading with the number of bytes of the attachment received so far,
+	// including Offset, and its total size, which is 0 if unknown.
+	Progress func(received, total int64)
+}
+
+// DownloadResult describes a finished download.
+type DownloadResult struct {
+	// Written is the number of bytes written to the writer.
+	Written int64
+	// Size is the total size of the attachment, or 0 if unknown.
+	Size        int64
+	ContentType string
+	// Checksum is the sum of DownloadOptions.Hash, or nil without a hash.
+	Checksum []byte
+}
+
+// DownloadAttachmentTo streams the content of an attachment to w.
+// If the server ignores the Range header of a resumed download, the skipped bytes are discarded.
+func (s *IssueService) DownloadAttachmentTo(ctx context.Context, attachmentID string, w io.Writer, options *DownloadOptions) (*DownloadResult, *Response, error) {
+	opts := DownloadOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	apiEndpoint := fmt.Sprintf("secure/attachment/%s/", attachmentID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if opts.Offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", opts.Offset))
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		if resp != nil && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && opts.Offset > 0 {
+			return s.completeDownload(ctx, attachmentID, resp, &opts, jerr)
+		}
+		return nil, resp, jerr
+	}
+	defer resp.Body.Close()
+
+	result := &DownloadResult{ContentType: resp.Header.Get("Content-Type")}
+	body := io.Reader(resp.Body)
+	if resp.StatusCode == http.StatusPartialContent {
+		result.Size = contentRangeSize(resp.Header.Get("Content-Range"))
+		if result.Size == 0 && resp.ContentLength >= 0 {
+			result.Size = opts.Offset + resp.ContentLength
+		}
+	} else {
+		if resp.ContentLength >= 0 {
+			result.Size = resp.ContentLength
+		}
+		if opts.Offset > 0 {
+			if _, err := io.CopyN(io.Discard, body, opts.Offset); err != nil {
+				return nil, resp, fmt.Errorf("skipping %d bytes of attachment %s: %w", opts.Offset, attachmentID, err)
+			}
+		}
+	}
+
+	dst := w
+	if opts.Hash != nil {
+		dst = io.MultiWriter(w, opts.Hash)
+	}
+	pw := &progressWriter{w: dst, sent: opts.Offset, total: result.Size, progress: opts.Progress}
+	n, err := io.Copy(pw, body)
+	result.Written = n
+	if err != nil {
+		return result, resp, err
+	}
+	if opts.Hash != nil {
+		result.Checksum = opts.Hash.Sum(nil)
+	}
+	return result, resp, nil
+}
+
+// completeDownload handles a resumed download that Jira rejected because the offset is at the end of the attachment,
+// which happens when a complete download is resumed. Any other rejected offset returns rangeErr.
+func (s *IssueService) completeDownload(ctx context.Context, attachmentID string, resp *Response, opts *DownloadOptions, rangeErr error) (*DownloadResult, *Response, error) {
+	size := contentRangeSize(resp.Header.Get("Content-Range"))
+	if size == 0 {
+		attachment, _, err := s.GetAttachment(ctx, attachmentID)
+		if err != nil {
+			return nil, resp, rangeErr
+		}
+		size = int64(attachment.Size)
+	}
+	if size != opts.Offset {
+		return nil, resp, rangeErr
+	}
+
+	result := &DownloadResult{Size: size}
+	if opts.Hash != nil {
+		result.Checksum = opts.Hash.Sum(nil)
+	}
+	if opts.Progress != nil {
+		opts.Progress(size, size)
+	}
+	return result, resp, nil
+}
+
+// contentRangeSize returns the complete length of a "bytes start-end/size" Content-Range header, or 0 if unknown
+func contentRangeSize(contentRange string) int64 {
+	i := strings.LastIndex(contentRange, "/")
+	if i < 0 {
+		return 0
+	}
+	size, err := strconv.ParseInt(contentRange[i+1:], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// DownloadAttachmentToFile downloads an attachment to the file at path.
+// If the file exists, the download resumes after its current content, which is also fed into options.Hash.
+// A file that is already complete is left unchanged.
+func (s *IssueService) DownloadAttachmentToFile(ctx context.Context, attachmentID, path string, options *DownloadOptions) (*DownloadResult, *Response, error) {
+	opts := DownloadOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	var existing io.Writer = io.Discard
+	if opts.Hash != nil {
+		existing = opts.Hash
+	}
+	opts.Offset, err = io.Copy(existing, f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, resp, err := s.DownloadAttachmentTo(ctx, attachmentID, f, &opts)
+	if err != nil {
+		return result, resp, err
+	}
+	return result, resp, f.Sync()
+}
+
+// GetAttachment returns the metadata of an attachment.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/attachment-getAttachment
+func (s *IssueService) GetAttachment(ctx context.Context, attachmentID string) (*Attachment, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/attachment/%s", attachmentID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment := new(Attachment)
+	resp, err := s.client.Do(req, attachment)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return attachment, resp, nil
+}
+
+// DownloadThumbnail streams the thumbnail of an image attachment to w and returns the number of bytes written.
+// Jira only creates thumbnails for images; Attachment.Thumbnail is empty for other attachments.
+func (s *IssueService) DownloadThumbnail(ctx context.Context, attachmentID string, w io.Writer) (int64, *Response, error) {
+	apiEndpoint := fmt.Sprintf("secure/thumbnail/%s/_thumb_%s.png", attachmentID, attachmentID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return 0, resp, jerr
+	}
+	defer resp.Body.Close()
+
+	n, err := io.Copy(w, resp.Body)
+	return n, resp, err
+}
+
+// AttachmentArchive is the human readable listing of the entries of an archive attachment, e.g. a zip file.
+type AttachmentArchive struct {
+	ID              int                      `json:"id" structs:"id"`
+	Name            string                   `json:"name" structs:"name"`
+	Entries         []AttachmentArchiveEntry `json:"entries" structs:"entries"`
+	TotalEntryCount int                      `json:"totalEntryCount" structs:"totalEntryCount"`
+	MediaType       string                   `json:"mediaType" structs:"mediaType"`
+}
+
+// AttachmentArchiveEntry is an entry of AttachmentArchive.
+type AttachmentArchiveEntry struct {
+	Path  string `json:"path" structs:"path"`
+	Index int    `json:"index" structs:"index"`
+	// Size is formatted for humans, e.g. "1.2 kB".
+	Size      string `json:"size" structs:"size"`
+	MediaType string `json:"mediaType" structs:"mediaType"`
+	Label     string `json:"label" structs:"label"`
+}
+
+// AttachmentArchiveRaw is the raw listing of the entries of an archive attachment.
+type AttachmentArchiveRaw struct {
+	Entries         []AttachmentArchiveRawEntry `json:"entries" structs:"entries"`
+	TotalEntryCount int                         `json:"totalEntryCount" structs:"totalEntryCount"`
+}
+
+// AttachmentArchiveRawEntry is an entry of AttachmentArchiveRaw.
+type AttachmentArchiveRawEntry struct {
+	EntryIndex      int    `json:"entryIndex" structs:"entryIndex"`
+	AbbreviatedName string `json:"abbreviatedName" structs:"abbreviatedName"`
+	Name            string `json:"name" structs:"name"`
+	Size            int64  `json:"size" structs:"size"`
+	MediaType       string `json:"mediaType" structs:"mediaType"`
+}
+
+// ExpandAttachmentForHumans lists the entries of an archive attachment with human readable sizes and labels.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/attachment-expandForHumans
+func (s *IssueService) ExpandAttachmentForHumans(ctx context.Context, attachmentID string) (*AttachmentArchive, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/attachment/%s/expand/human", attachmentID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	archive := new(AttachmentArchive)
+	resp, err := s.client.Do(req, archive)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return archive, resp, nil
+}
+
+// ExpandAttachmentForMachines lists the entries of an archive attachment with their sizes in bytes.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/attachment-expandForMachines
+func (s *IssueService) ExpandAttachmentForMachines(ctx context.Context, attachmentID string) (*AttachmentArchiveRaw, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/attachment/%s/expand/raw", attachmentID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	archive := new(AttachmentArchiveRaw)
+	resp, err := s.client.Do(req, archive)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return archive, resp, nil
+}
+
+// MirrorResult lists the files written and skipped by IssueService.MirrorAttachments.
+type MirrorResult struct {
+	Downloaded []string
+	Skipped    []string
+	// Linked are the downloaded files which were replaced by a hard link to a file with the same content.
+	Linked []string
+}
+
+// MirrorAttachments downloads the attachments of all issues matching jql to dir.
+// Every attachment is stored as <dir>/<issue key>/<attachment ID>_<filename>, so attachments with the same
+// name never collide. Files which already exist with the size of the attachment are skipped and
+// partially downloaded files are resumed, so repeated runs only fetch new attachments.
+//
+// Attachments with identical content, e.g. those copied to cloned issues, are stored once: a downloaded file
+// with the same size and SHA-256 checksum as an already mirrored file is replaced by a hard link to it.
+// Jira does not provide checksums of attachments, so duplicates are still downloaded to compare them.
+func (s *IssueService) MirrorAttachments(ctx context.Context, jql, dir string) (*MirrorResult, error) {
+	result := &MirrorResult{}
+	bySize := make(map[int64][]string)
+	checksums := make(map[string]string)
+
+	err := s.SearchPages(ctx, jql, &SearchOptions{MaxResults: 50, Fields: []string{"attachment"}}, func(issue Issue) error {
+		if issue.Fields == nil {
+			return nil
+		}
+		for _, a := range issue.Fields.Attachments {
+			if a == nil {
+				continue
+			}
+
+			issueDir := filepath.Join(dir, sanitizeFilename(issue.Key))
+			if err := os.MkdirAll(issueDir, 0o755); err != nil {
+				return err
+			}
+			path := filepath.Join(issueDir, sanitizeFilename(a.ID+"_"+a.Filename))
+			size := int64(a.Size)
+
+			if info, err := os.Stat(path); err == nil {
+				if info.Size() == size {
+					result.Skipped = append(result.Skipped, path)
+					bySize[size] = append(bySize[size], path)
+					continue
+				}
+				if info.Size() > size {
+					if err := os.Remove(path); err != nil {
+						return err
+					}
+				}
+			}
+
+			download, _, err := s.DownloadAttachmentToFile(ctx, a.ID, path, &DownloadOptions{Hash: sha256.New()})
+			if err != nil {
+				return fmt.Errorf("downloading attachment %s of %s: %w", a.ID, issue.Key, err)
+			}
+			result.Downloaded = append(result.Downloaded, path)
+			checksums[path] = hex.EncodeToString(download.Checksum)
+
+			linked, err := linkDuplicate(path, bySize[size], checksums)
+			if err != nil {
+				return err
+			}
+			if linked {
+				result.Linked = append(result.Linked, path)
+			}
+			bySize[size] = append(bySize[size], path)
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// linkDuplicate replaces the file at path by a hard link to the first of the candidates with the same checksum.
+// Checksums of the candidates are computed on demand and cached in checksums.
+// If the file system does not support hard links, the file is kept.
+func linkDuplicate(path string, candidates []string, checksums map[string]string) (bool, error) {
+	for _, candidate := range candidates {
+		sum, ok := checksums[candidate]
+		if !ok {
+			var err error
+			if sum, err = fileChecksum(candidate); err != nil {
+				return false, err
+			}
+			checksums[candidate] = sum
+		}
+		if sum != checksums[path] {
+			continue
+		}
+
+		tmp := path + ".link"
+		os.Remove(tmp)
+		if err := os.Link(candidate, tmp); err != nil {
+			return false, nil
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			os.Remove(tmp)
+			return false, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// fileChecksum returns the hex encoded SHA-256 checksum of the file at path
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// sanitizeFilename replaces characters which are not allowed in file names
+func sanitizeFilename(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|', 0:
+			return '_'
+		}
+		return r
+	}, name)
+}
@@ -0,0 +1,306 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIssueService_UploadAttachments(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/10000/attachments", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		if got := r.Header.Get("X-Atlassian-Token"); got != "nocheck" {
+			t.Errorf("Expected X-Atlassian-Token nocheck, got %q", got)
+		}
+
+		reader, err := r.MultipartReader()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var parts []string
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, _ := io.ReadAll(part)
+			parts = append(parts, fmt.Sprintf("%s|%s|%s|%s", part.FormName(), part.FileName(), part.Header.Get("Content-Type"), data))
+		}
+		want := []string{
+			"file|notes.txt|text/plain; charset=utf-8|hello",
+			"file|dump|application/octet-stream|\x00\x01\x02",
+		}
+		if fmt.Sprint(parts) != fmt.Sprint(want) {
+			t.Errorf("Expected parts %q, got %q", want, parts)
+		}
+		fmt.Fprint(w, `[{"id":"1","filename":"notes.txt"},{"id":"2","filename":"dump"}]`)
+	})
+
+	var progress []int64
+	attachments, _, err := testClient.Issue.UploadAttachments(context.Background(), "10000", []AttachmentFile{
+		{Name: "notes.txt", Reader: strings.NewReader("hello"), Size: 5},
+		{Name: "dump", Reader: bytes.NewReader([]byte{0, 1, 2}), Size: 3},
+	}, &UploadOptions{Progress: func(sent, total int64) {
+		if total != 8 {
+			t.Errorf("Expected a total of 8 bytes, got %d", total)
+		}
+		progress = append(progress, sent)
+	}})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(attachments) != 2 {
+		t.Errorf("Expected 2 attachments, got %+v", attachments)
+	}
+	if len(progress) == 0 || progress[len(progress)-1] != 8 {
+		t.Errorf("Expected progress to reach 8 bytes, got %v", progress)
+	}
+}
+
+func TestIssueService_UploadAttachments_CheckSize(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/attachment/meta", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"enabled":true,"uploadLimit":4}`)
+	})
+	testMux.HandleFunc("/rest/api/2/issue/10000/attachments", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	// known size
+	_, _, err := testClient.Issue.UploadAttachments(context.Background(), "10000", []AttachmentFile{
+		{Name: "big.txt", Reader: strings.NewReader("hello"), Size: 5},
+	}, &UploadOptions{CheckSize: true})
+	if err == nil || !strings.Contains(err.Error(), "upload limit") {
+		t.Errorf("Expected an upload limit error, got %v", err)
+	}
+
+	// unknown size is checked while streaming
+	_, _, err = testClient.Issue.UploadAttachments(context.Background(), "10000", []AttachmentFile{
+		{Name: "big.txt", Reader: strings.NewReader("hello")},
+	}, &UploadOptions{CheckSize: true})
+	if err == nil {
+		t.Error("Expected an error for an attachment exceeding the upload limit")
+	}
+}
+
+func TestIssueService_DownloadAttachmentTo(t *testing.T) {
+	setup()
+	defer teardown()
+	content := "0123456789"
+	testMux.HandleFunc("/secure/attachment/10000/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if r.Header.Get("Range") == "bytes=4-" {
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes 4-9/%d", len(content)))
+			w.WriteHeader(http.StatusPartialContent)
+			fmt.Fprint(w, content[4:])
+			return
+		}
+		fmt.Fprint(w, content)
+	})
+
+	var buf bytes.Buffer
+	result, _, err := testClient.Issue.DownloadAttachmentTo(context.Background(), "10000", &buf, &DownloadOptions{Hash: sha256.New()})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	sum := sha256.Sum256([]byte(content))
+	if buf.String() != content || result.Written != 10 || result.Size != 10 || !bytes.Equal(result.Checksum, sum[:]) {
+		t.Errorf("Unexpected download %q, %+v", buf.String(), result)
+	}
+
+	// resume
+	h := sha256.New()
+	h.Write([]byte(content[:4]))
+	buf.Reset()
+	var received int64
+	result, _, err = testClient.Issue.DownloadAttachmentTo(context.Background(), "10000", &buf, &DownloadOptions{
+		Offset:   4,
+		Hash:     h,
+		Progress: func(n, total int64) { received = n },
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if buf.String() != content[4:] || result.Size != 10 || received != 10 || !bytes.Equal(result.Checksum, sum[:]) {
+		t.Errorf("Unexpected resumed download %q, %+v", buf.String(), result)
+	}
+}
+
+func TestIssueService_DownloadAttachmentToFile_Complete(t *testing.T) {
+	setup()
+	defer teardown()
+	content := "0123456789"
+	testMux.HandleFunc("/secure/attachment/10000/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "bytes=10-" {
+			t.Errorf("Expected the download to resume at the end of the file, got Range %q", r.Header.Get("Range"))
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", len(content)))
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+	})
+
+	path := filepath.Join(t.TempDir(), "complete.txt")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	result, _, err := testClient.Issue.DownloadAttachmentToFile(context.Background(), "10000", path, &DownloadOptions{Hash: sha256.New()})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	sum := sha256.Sum256([]byte(content))
+	if result.Written != 0 || result.Size != 10 || !bytes.Equal(result.Checksum, sum[:]) {
+		t.Errorf("Unexpected result for a complete file %+v", result)
+	}
+	if data, _ := os.ReadFile(path); string(data) != content {
+		t.Errorf("Expected the complete file to be unchanged, got %q", data)
+	}
+}
+
+func TestIssueService_DownloadAttachmentToFile_RangeIgnored(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/secure/attachment/10000/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "0123456789")
+	})
+
+	path := filepath.Join(t.TempDir(), "download")
+	if err := os.WriteFile(path, []byte("0123"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, _, err := testClient.Issue.DownloadAttachmentToFile(context.Background(), "10000", path, nil)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "0123456789" || result.Written != 6 {
+		t.Errorf("Unexpected file content %q, %+v", data, result)
+	}
+}
+
+func TestIssueService_GetAttachment(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/attachment/10000", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"id":"10000","filename":"picture.jpg","size":23123,"mimeType":"image/jpeg"}`)
+	})
+
+	attachment, _, err := testClient.Issue.GetAttachment(context.Background(), "10000")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if attachment.Filename != "picture.jpg" || attachment.Size != 23123 {
+		t.Errorf("Unexpected attachment %+v", attachment)
+	}
+}
+
+func TestIssueService_DownloadThumbnail(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/secure/thumbnail/10000/_thumb_10000.png", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, "png")
+	})
+
+	var buf bytes.Buffer
+	n, _, err := testClient.Issue.DownloadThumbnail(context.Background(), "10000", &buf)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if n != 3 || buf.String() != "png" {
+		t.Errorf("Unexpected thumbnail %q", buf.String())
+	}
+}
+
+func TestIssueService_ExpandAttachment(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/attachment/10000/expand/human", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"id":10000,"name":"logs.zip","entries":[{"path":"logs/app.log","index":0,"size":"1.2 kB","mediaType":"text/plain","label":"app.log"}],"totalEntryCount":1,"mediaType":"application/zip"}`)
+	})
+	testMux.HandleFunc("/rest/api/2/attachment/10000/expand/raw", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"entries":[{"entryIndex":0,"abbreviatedName":"app.log","name":"logs/app.log","size":1234,"mediaType":"text/plain"}],"totalEntryCount":1}`)
+	})
+
+	human, _, err := testClient.Issue.ExpandAttachmentForHumans(context.Background(), "10000")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if human.Name != "logs.zip" || len(human.Entries) != 1 || human.Entries[0].Size != "1.2 kB" {
+		t.Errorf("Unexpected archive %+v", human)
+	}
+
+	raw, _, err := testClient.Issue.ExpandAttachmentForMachines(context.Background(), "10000")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if raw.TotalEntryCount != 1 || raw.Entries[0].Size != 1234 || raw.Entries[0].Name != "logs/app.log" {
+		t.Errorf("Unexpected archive %+v", raw)
+	}
+}
+
+func TestIssueService_MirrorAttachments(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"startAt":0,"maxResults":50,"total":2,"issues":[
+			{"key":"TEST-1","fields":{"attachment":[{"id":"1","filename":"a.txt","size":3},{"id":"2","filename":"a.txt","size":3}]}},
+			{"key":"TEST-2","fields":{"attachment":[{"id":"3","filename":"b/c.txt","size":3}]}}]}`)
+	})
+	downloads := 0
+	for id, content := range map[string]string{"1": "ab1", "2": "ab2", "3": "ab1"} {
+		content := content
+		testMux.HandleFunc("/secure/attachment/"+id+"/", func(w http.ResponseWriter, r *http.Request) {
+			downloads++
+			fmt.Fprint(w, content)
+		})
+	}
+
+	dir := t.TempDir()
+	result, err := testClient.Issue.MirrorAttachments(context.Background(), "project = TEST", dir)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(result.Downloaded) != 3 || downloads != 3 {
+		t.Fatalf("Expected 3 downloads, got %+v", result)
+	}
+	duplicate := filepath.Join(dir, "TEST-2", "3_b_c.txt")
+	data, err := os.ReadFile(duplicate)
+	if err != nil || string(data) != "ab1" {
+		t.Errorf("Unexpected mirrored file %q (%v)", data, err)
+	}
+	if !reflect.DeepEqual(result.Linked, []string{duplicate}) {
+		t.Errorf("Expected the duplicate of attachment 1 to be linked, got %v", result.Linked)
+	}
+	first, _ := os.Stat(filepath.Join(dir, "TEST-1", "1_a.txt"))
+	linked, _ := os.Stat(duplicate)
+	if first == nil || linked == nil || !os.SameFile(first, linked) {
+		t.Error("Expected identical attachments to share a file")
+	}
+
+	result, err = testClient.Issue.MirrorAttachments(context.Background(), "project = TEST", dir)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(result.Skipped) != 3 || len(result.Downloaded) != 0 || downloads != 3 {
+		t.Errorf("Expected all attachments to be skipped on the second run, got %+v", result)
+	}
+}
@@ -2,8 +2,10 @@ package jira
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -63,14 +65,16 @@ type SprintsList struct {
 
 // Sprint represents a sprint on Jira agile board
 type Sprint struct {
-	ID            int        `json:"id" structs:"id"`
-	Name          string     `json:"name" structs:"name"`
-	CompleteDate  *time.Time `json:"completeDate" structs:"completeDate"`
-	EndDate       *time.Time `json:"endDate" structs:"endDate"`
-	StartDate     *time.Time `json:"startDate" structs:"startDate"`
-	OriginBoardID int        `json:"originBoardId" structs:"originBoardId"`
-	Self          string     `json:"self" structs:"self"`
-	State         string     `json:"state" structs:"state"`
+	ID            int        `json:"id,omitempty" structs:"id"`
+	Name          string     `json:"name,omitempty" structs:"name"`
+	Goal          string     `json:"goal,omitempty" structs:"goal"`
+	CreatedDate   *time.Time `json:"createdDate,omitempty" structs:"createdDate"`
+	CompleteDate  *time.Time `json:"completeDate,omitempty" structs:"completeDate"`
+	EndDate       *time.Time `json:"endDate,omitempty" structs:"endDate"`
+	StartDate     *time.Time `json:"startDate,omitempty" structs:"startDate"`
+	OriginBoardID int        `json:"originBoardId,omitempty" structs:"originBoardId"`
+	Self          string     `json:"self,omitempty" structs:"self"`
+	State         string     `json:"state,omitempty" structs:"state"`
 }
 
 // BoardConfiguration represents a boardConfiguration of a jira board
@@ -78,10 +82,13 @@ type BoardConfiguration struct {
 	ID           int                            `json:"id"`
 	Name         string                         `json:"name"`
 	Self         string                         `json:"self"`
+	Type         string                         `json:"type"`
 	Location     BoardConfigurationLocation     `json:"location"`
 	Filter       BoardConfigurationFilter       `json:"filter"`
 	SubQuery     BoardConfigurationSubQuery     `json:"subQuery"`
 	ColumnConfig BoardConfigurationColumnConfig `json:"columnConfig"`
+	Estimation   BoardConfigurationEstimation   `json:"estimation"`
+	Ranking      BoardConfigurationRanking      `json:"ranking"`
 }
 
 // BoardConfigurationFilter reference to the filter used by the given board.
@@ -119,6 +126,41 @@ type BoardConfigurationColumn struct {
 	Max    int                              `json:"max,omitempty"`
 }
 
+// BoardConfigurationEstimation describes how the issues of the board are estimated.
+// Type is "field" when a field such as the story points is used, or "issueCount" when issues are counted.
+type BoardConfigurationEstimation struct {
+	Type  string                            `json:"type"`
+	Field BoardConfigurationEstimationField `json:"field"`
+}
+
+// BoardConfigurationEstimationField references the field used for estimation, e.g. "Story Points".
+type BoardConfigurationEstimationField struct {
+	FieldID     string `json:"fieldId"`
+	DisplayName string `json:"displayName"`
+}
+
+// BoardConfigurationRanking references the custom field used to rank the issues of the board.
+type BoardConfigurationRanking struct {
+	RankCustomFieldID int `json:"rankCustomFieldId"`
+}
+
+// BoardColumnWIP is the work in progress of a board column compared to its constraints.
+type BoardColumnWIP struct {
+	Column BoardConfigurationColumn
+	// Count is the number of issues in the column.
+	// Sub-tasks are not counted if the constraint type is issueCountExclSubs.
+	Count int
+	// BelowMin is true if the column has a minimum constraint and holds fewer issues.
+	BelowMin bool
+	// AboveMax is true if the column has a maximum constraint and holds more issues.
+	AboveMax bool
+}
+
+// Violated reports whether the column violates its Min or Max constraint.
+func (w BoardColumnWIP) Violated() bool {
+	return w.BelowMin || w.AboveMax
+}
+
 // BoardConfigurationColumnStatus represents a status in the column configuration
 type BoardConfigurationColumnStatus struct {
 	ID   string `json:"id"`
@@ -257,3 +299,416 @@ func (s *BoardService) GetBoardConfiguration(ctx context.Context, boardID int) (
 	return result, resp, err
 
 }
+
+// BoardSwimlanes describes how the issues of a board are grouped into swimlanes.
+// Strategy is one of "none", "custom", "parentChild", "assignee", "assigneeUnassignedFirst", "epic" or "project".
+type BoardSwimlanes struct {
+	Strategy string `json:"swimlaneStrategy"`
+	// Swimlanes are the queries of the "custom" strategy in board order.
+	Swimlanes []BoardSwimlane `json:"swimlanes"`
+}
+
+// BoardSwimlane is a query based swimlane of a board.
+// The default swimlane holds the issues not matched by any other swimlane.
+type BoardSwimlane struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Query       string `json:"query"`
+	Description string `json:"description"`
+	IsDefault   bool   `json:"isDefault"`
+}
+
+// GetBoardSwimlanes returns the swimlane configuration of a board.
+// Swimlanes are not part of the agile REST API, so they are read from the board edit model of Jira Software,
+// which is available on Jira Cloud and Jira Server / Data Center but is not a documented API.
+// The user needs permission to view the board configuration.
+func (s *BoardService) GetBoardSwimlanes(ctx context.Context, boardID int) (*BoardSwimlanes, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/greenhopper/1.0/rapidviewconfig/editmodel.json?rapidViewId=%d", boardID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(struct {
+		SwimlanesConfig BoardSwimlanes `json:"swimlanesConfig"`
+	})
+	resp, err := s.client.Do(req, result)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return &result.SwimlanesConfig, resp, nil
+}
+
+// BoardSprintReport lists the issues of a sprint as shown by the sprint report of a board.
+type BoardSprintReport struct {
+	CompletedIssues []BoardSprintReportIssue `json:"completedIssues"`
+	// NotCompletedIssues are the issues in the sprint which were not done when it ended.
+	NotCompletedIssues []BoardSprintReportIssue `json:"issuesNotCompletedInCurrentSprint"`
+	// PuntedIssues are the issues removed from the sprint after it started.
+	PuntedIssues                   []BoardSprintReportIssue `json:"puntedIssues"`
+	IssuesCompletedInAnotherSprint []BoardSprintReportIssue `json:"issuesCompletedInAnotherSprint"`
+	// IssueKeysAddedDuringSprint holds the keys of the issues added after the sprint started.
+	IssueKeysAddedDuringSprint map[string]bool `json:"issueKeysAddedDuringSprint"`
+}
+
+// BoardSprintReportIssue is an issue of a BoardSprintReport.
+type BoardSprintReportIssue struct {
+	ID      int    `json:"id"`
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	Done    bool   `json:"done"`
+}
+
+// GetSprintReport returns the sprint report of a sprint of the board.
+// Like GetBoardSwimlanes, it reads a Jira Software endpoint which is not part of the agile REST API.
+// It is the only way to list the issues removed from a sprint, which JQL cannot find.
+func (s *BoardService) GetSprintReport(ctx context.Context, boardID, sprintID int) (*BoardSprintReport, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/greenhopper/1.0/rapid/charts/sprintreport?rapidViewId=%d&sprintId=%d", boardID, sprintID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(struct {
+		Contents BoardSprintReport `json:"contents"`
+	})
+	resp, err := s.client.Do(req, result)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return &result.Contents, resp, nil
+}
+
+// BoardIssuesOptions specifies the optional parameters to the BoardService methods returning issues,
+// like GetBoardIssues, GetBacklogIssues and GetIssuesForEpic
+type BoardIssuesOptions struct {
+	// JQL filters results to issues that match the JQL query, in addition to the board filter.
+	JQL string `url:"jql,omitempty"`
+	// ValidateQuery specifies whether to validate the JQL query. Default: true.
+	ValidateQuery *bool `url:"validateQuery,omitempty"`
+	// Fields is a comma-separated list of fields to return for each issue. By default, all navigable fields are returned.
+	Fields string `url:"fields,omitempty"`
+	// Expand is a comma-separated list of the parameters to expand.
+	Expand string `url:"expand,omitempty"`
+	// StartAt is the starting index of the returned issues. Base index: 0.
+	StartAt int `url:"startAt,omitempty"`
+	// MaxResults is the maximum number of issues to return per page. Default: 50.
+	MaxResults int `url:"maxResults,omitempty"`
+}
+
+// GetEpicsOptions specifies the optional parameters to the BoardService.GetEpics
+type GetEpicsOptions struct {
+	// Done filters results to epics that are either done or not done.
+	// Valid values: true, false.
+	Done string `url:"done,omitempty"`
+
+	SearchOptions
+}
+
+// EpicsList reflects a list of agile epics
+type EpicsList struct {
+	MaxResults int    `json:"maxResults" structs:"maxResults"`
+	StartAt    int    `json:"startAt" structs:"startAt"`
+	Total      int    `json:"total" structs:"total"`
+	IsLast     bool   `json:"isLast" structs:"isLast"`
+	Values     []Epic `json:"values" structs:"values"`
+}
+
+// GetBoardVersionsOptions specifies the optional parameters to the BoardService.GetVersions
+type GetBoardVersionsOptions struct {
+	// Released filters results to versions that are either released or unreleased.
+	// Valid values: true, false.
+	Released string `url:"released,omitempty"`
+
+	SearchOptions
+}
+
+// BoardVersionsList reflects a list of versions of an agile board
+type BoardVersionsList struct {
+	MaxResults int       `json:"maxResults" structs:"maxResults"`
+	StartAt    int       `json:"startAt" structs:"startAt"`
+	Total      int       `json:"total" structs:"total"`
+	IsLast     bool      `json:"isLast" structs:"isLast"`
+	Values     []Version `json:"values" structs:"values"`
+}
+
+// BoardProjectsList reflects a list of projects associated with an agile board
+type BoardProjectsList struct {
+	MaxResults int       `json:"maxResults" structs:"maxResults"`
+	StartAt    int       `json:"startAt" structs:"startAt"`
+	Total      int       `json:"total" structs:"total"`
+	IsLast     bool      `json:"isLast" structs:"isLast"`
+	Values     []Project `json:"values" structs:"values"`
+}
+
+// RankIssuesPayload is the request payload of BoardService.RankIssues.
+// Exactly one of RankBeforeIssue and RankAfterIssue must be set.
+type RankIssuesPayload struct {
+	Issues            []string `json:"issues"`
+	RankBeforeIssue   string   `json:"rankBeforeIssue,omitempty"`
+	RankAfterIssue    string   `json:"rankAfterIssue,omitempty"`
+	RankCustomFieldID int      `json:"rankCustomFieldId,omitempty"`
+}
+
+// RankIssuesResult is returned by BoardService.RankIssues if ranking failed for some of the issues
+type RankIssuesResult struct {
+	Entries []RankIssuesEntry `json:"entries"`
+}
+
+// RankIssuesEntry is the ranking result of a single issue
+type RankIssuesEntry struct {
+	IssueID  int      `json:"issueId"`
+	IssueKey string   `json:"issueKey"`
+	Status   int      `json:"status"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// getIssues returns the issues of one of the agile issue endpoints of a board
+func (s *BoardService) getIssues(ctx context.Context, apiEndpoint string, options *BoardIssuesOptions) ([]Issue, *Response, error) {
+	url, err := addOptions(apiEndpoint, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(searchResult)
+	resp, err := s.client.Do(req, result)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return result.Issues, resp, nil
+}
+
+// GetBoardIssues returns all issues from a board, for a given board ID.
+// This only includes issues that the user has permission to view.
+// Issues can be further filtered with options.JQL. Paging information is available in the returned Response.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/board-getIssuesForBoard
+func (s *BoardService) GetBoardIssues(ctx context.Context, boardID int, options *BoardIssuesOptions) ([]Issue, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/agile/1.0/board/%d/issue", boardID)
+	return s.getIssues(ctx, apiEndpoint, options)
+}
+
+// GetBacklogIssues returns all issues from the board's backlog, for a given board ID.
+// This only includes issues that the user has permission to view.
+// The backlog contains incomplete issues that are not assigned to any future or active sprint.
+// By default, the returned issues are ordered by rank.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/board-getIssuesForBacklog
+func (s *BoardService) GetBacklogIssues(ctx context.Context, boardID int, options *BoardIssuesOptions) ([]Issue, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/agile/1.0/board/%d/backlog", boardID)
+	return s.getIssues(ctx, apiEndpoint, options)
+}
+
+// GetEpics returns all epics from the board, for the given board ID.
+// This only includes epics that the user has permission to view.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/board/{boardId}/epic-getEpics
+func (s *BoardService) GetEpics(ctx context.Context, boardID int, options *GetEpicsOptions) (*EpicsList, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/agile/1.0/board/%d/epic", boardID)
+	url, err := addOptions(apiEndpoint, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(EpicsList)
+	resp, err := s.client.Do(req, result)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return result, resp, nil
+}
+
+// GetIssuesForEpic returns all issues that belong to the epic, for the given board and epic ID.
+// This only includes issues that the user has permission to view.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/board/{boardId}/epic-getIssuesForEpic
+func (s *BoardService) GetIssuesForEpic(ctx context.Context, boardID, epicID int, options *BoardIssuesOptions) ([]Issue, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/agile/1.0/board/%d/epic/%d/issue", boardID, epicID)
+	return s.getIssues(ctx, apiEndpoint, options)
+}
+
+// GetIssuesWithoutEpic returns all issues of the board that do not belong to any epic.
+// This only includes issues that the user has permission to view.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/board/{boardId}/epic-getIssuesWithoutEpic
+func (s *BoardService) GetIssuesWithoutEpic(ctx context.Context, boardID int, options *BoardIssuesOptions) ([]Issue, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/agile/1.0/board/%d/epic/none/issue", boardID)
+	return s.getIssues(ctx, apiEndpoint, options)
+}
+
+// GetVersions returns all versions from a board, for a given board ID.
+// This only includes versions that the user has permission to view.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/board-getAllVersions
+func (s *BoardService) GetVersions(ctx context.Context, boardID int, options *GetBoardVersionsOptions) (*BoardVersionsList, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/agile/1.0/board/%d/version", boardID)
+	url, err := addOptions(apiEndpoint, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(BoardVersionsList)
+	resp, err := s.client.Do(req, result)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return result, resp, nil
+}
+
+// GetProjects returns all projects that are associated with the board, for the given board ID.
+// A project is associated with a board if the board filter contains reference the project or
+// if there is an issue from the project that belongs to the board.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/board-getProjects
+func (s *BoardService) GetProjects(ctx context.Context, boardID int, options *SearchOptions) (*BoardProjectsList, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/agile/1.0/board/%d/project", boardID)
+	url, err := addOptions(apiEndpoint, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(BoardProjectsList)
+	resp, err := s.client.Do(req, result)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return result, resp, nil
+}
+
+// RankIssues moves (ranks) issues before or after a given issue.
+// At most 50 issues may be ranked at once.
+// If ranking fails for some of the issues, the returned RankIssuesResult lists them and an error is returned.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/issue-rankIssues
+func (s *BoardService) RankIssues(ctx context.Context, payload *RankIssuesPayload) (*RankIssuesResult, *Response, error) {
+	apiEndpoint := "rest/agile/1.0/issue/rank"
+	req, err := s.client.NewRequest(ctx, http.MethodPut, apiEndpoint, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+	defer resp.Body.Close()
+
+	// Jira answers with 204 No Content if all issues were ranked and with 207 Multi-Status otherwise
+	if resp.StatusCode != http.StatusMultiStatus {
+		return &RankIssuesResult{}, resp, nil
+	}
+
+	result := new(RankIssuesResult)
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, resp, err
+	}
+	var failed []string
+	for _, entry := range result.Entries {
+		if len(entry.Errors) > 0 {
+			failed = append(failed, fmt.Sprintf("%s: %s", entry.IssueKey, strings.Join(entry.Errors, ", ")))
+		}
+	}
+	if len(failed) > 0 {
+		return result, resp, fmt.Errorf("ranking failed for %d issues: %s", len(failed), strings.Join(failed, "; "))
+	}
+	return result, resp, nil
+}
+
+// RankIssuesBefore ranks the issues before the issue rankBeforeIssue.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/issue-rankIssues
+func (s *BoardService) RankIssuesBefore(ctx context.Context, issues []string, rankBeforeIssue string) (*RankIssuesResult, *Response, error) {
+	return s.RankIssues(ctx, &RankIssuesPayload{Issues: issues, RankBeforeIssue: rankBeforeIssue})
+}
+
+// RankIssuesAfter ranks the issues after the issue rankAfterIssue.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/issue-rankIssues
+func (s *BoardService) RankIssuesAfter(ctx context.Context, issues []string, rankAfterIssue string) (*RankIssuesResult, *Response, error) {
+	return s.RankIssues(ctx, &RankIssuesPayload{Issues: issues, RankAfterIssue: rankAfterIssue})
+}
+
+// IsKanban reports whether the board is a Kanban board.
+// Only Kanban boards have a sub-query and a column constraint on the first and last column.
+func (c *BoardConfiguration) IsKanban() bool {
+	return c.Type == "kanban"
+}
+
+// ColumnForStatus returns the column the status with the given ID is mapped to.
+// If the status is not mapped to any column, nil is returned.
+func (c *BoardConfiguration) ColumnForStatus(statusID string) *BoardConfigurationColumn {
+	for i, column := range c.ColumnConfig.Columns {
+		for _, status := range column.Status {
+			if status.ID == statusID {
+				return &c.ColumnConfig.Columns[i]
+			}
+		}
+	}
+	return nil
+}
+
+// ColumnWIP counts the given issues per column and compares the counts with the Min and Max constraints of the columns.
+// Issues whose status is not mapped to a column are ignored.
+// If the board has no constraint type ("none"), the counts are returned without being checked.
+func (c *BoardConfiguration) ColumnWIP(issues []Issue) []BoardColumnWIP {
+	counts := make(map[string]int)
+	for _, issue := range issues {
+		if issue.Fields == nil || issue.Fields.Status == nil {
+			continue
+		}
+		if c.ColumnConfig.ConstraintType == "issueCountExclSubs" && issue.Fields.Type.Subtask {
+			continue
+		}
+		if column := c.ColumnForStatus(issue.Fields.Status.ID); column != nil {
+			counts[column.Name]++
+		}
+	}
+
+	checked := c.ColumnConfig.ConstraintType != "" && c.ColumnConfig.ConstraintType != "none"
+	wip := make([]BoardColumnWIP, 0, len(c.ColumnConfig.Columns))
+	for _, column := range c.ColumnConfig.Columns {
+		w := BoardColumnWIP{Column: column, Count: counts[column.Name]}
+		if checked {
+			w.BelowMin = column.Min > 0 && w.Count < column.Min
+			w.AboveMax = column.Max > 0 && w.Count > column.Max
+		}
+		wip = append(wip, w)
+	}
+	return wip
+}
+
+// WIPViolations returns the columns whose number of issues violates their Min or Max constraint.
+func (c *BoardConfiguration) WIPViolations(issues []Issue) []BoardColumnWIP {
+	var violations []BoardColumnWIP
+	for _, w := range c.ColumnWIP(issues) {
+		if w.Violated() {
+			violations = append(violations, w)
+		}
+	}
+	return violations
+}
@@ -2,6 +2,7 @@ package jira
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -233,3 +234,341 @@ func TestBoardService_GetBoardConfigoration(t *testing.T) {
 		t.Errorf("Expected a max of 0 issues in progress. Got %d", inProgressColumn.Max)
 	}
 }
+
+func TestBoardService_GetBoardIssues(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEndpoint := "/rest/agile/1.0/board/1/issue"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testRequestURL(t, r, testAPIEndpoint)
+		testRequestParams(t, r, map[string]string{"jql": "assignee = currentUser()", "fields": "summary", "startAt": "50", "maxResults": "50"})
+		fmt.Fprint(w, `{"expand":"names,schema","startAt":50,"maxResults":50,"total":51,"issues":[{"id":"10001","key":"HSP-1","fields":{"summary":"First"}}]}`)
+	})
+
+	issues, resp, err := testClient.Board.GetBoardIssues(context.Background(), 1, &BoardIssuesOptions{
+		JQL:        "assignee = currentUser()",
+		Fields:     "summary",
+		StartAt:    50,
+		MaxResults: 50,
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(issues) != 1 || issues[0].Key != "HSP-1" {
+		t.Errorf("Expected issue HSP-1, got %+v", issues)
+	}
+	if resp.Total != 51 || resp.StartAt != 50 {
+		t.Errorf("Expected paging information in response, got total %d and startAt %d", resp.Total, resp.StartAt)
+	}
+}
+
+func TestBoardService_GetBacklogIssues(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEndpoint := "/rest/agile/1.0/board/1/backlog"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testRequestURL(t, r, testAPIEndpoint)
+		fmt.Fprint(w, `{"startAt":0,"maxResults":50,"total":2,"issues":[{"key":"HSP-1"},{"key":"HSP-2"}]}`)
+	})
+
+	issues, _, err := testClient.Board.GetBacklogIssues(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(issues) != 2 {
+		t.Errorf("Expected 2 backlog issues, got %d", len(issues))
+	}
+}
+
+func TestBoardService_GetEpics(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEndpoint := "/rest/agile/1.0/board/1/epic"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testRequestURL(t, r, testAPIEndpoint)
+		testRequestParams(t, r, map[string]string{"done": "false"})
+		fmt.Fprint(w, `{"maxResults":2,"startAt":0,"isLast":true,"values":[{"id":37,"self":"http://www.example.com/jira/rest/agile/1.0/epic/23","name":"epic 1","summary":"epic 1 summary","color":{"key":"color_4"},"done":false}]}`)
+	})
+
+	epics, _, err := testClient.Board.GetEpics(context.Background(), 1, &GetEpicsOptions{Done: "false"})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(epics.Values) != 1 || epics.Values[0].ID != 37 {
+		t.Errorf("Expected epic 37, got %+v", epics.Values)
+	}
+}
+
+func TestBoardService_GetIssuesForEpic(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEndpoint := "/rest/agile/1.0/board/1/epic/37/issue"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testRequestURL(t, r, testAPIEndpoint)
+		fmt.Fprint(w, `{"startAt":0,"maxResults":50,"total":1,"issues":[{"key":"HSP-3"}]}`)
+	})
+
+	issues, _, err := testClient.Board.GetIssuesForEpic(context.Background(), 1, 37, nil)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(issues) != 1 || issues[0].Key != "HSP-3" {
+		t.Errorf("Expected issue HSP-3, got %+v", issues)
+	}
+}
+
+func TestBoardService_GetIssuesWithoutEpic(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEndpoint := "/rest/agile/1.0/board/1/epic/none/issue"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testRequestURL(t, r, testAPIEndpoint)
+		fmt.Fprint(w, `{"startAt":0,"maxResults":50,"total":1,"issues":[{"key":"HSP-4"}]}`)
+	})
+
+	issues, _, err := testClient.Board.GetIssuesWithoutEpic(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(issues) != 1 || issues[0].Key != "HSP-4" {
+		t.Errorf("Expected issue HSP-4, got %+v", issues)
+	}
+}
+
+func TestBoardService_GetVersions(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEndpoint := "/rest/agile/1.0/board/1/version"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testRequestURL(t, r, testAPIEndpoint)
+		testRequestParams(t, r, map[string]string{"released": "true"})
+		fmt.Fprint(w, `{"maxResults":10,"startAt":0,"isLast":true,"values":[{"self":"http://www.example.com/jira/version/10000","id":"10000","projectId":10000,"name":"Version 1","released":true}]}`)
+	})
+
+	versions, _, err := testClient.Board.GetVersions(context.Background(), 1, &GetBoardVersionsOptions{Released: "true"})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(versions.Values) != 1 || versions.Values[0].Name != "Version 1" {
+		t.Errorf("Expected Version 1, got %+v", versions.Values)
+	}
+}
+
+func TestBoardService_GetProjects(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEndpoint := "/rest/agile/1.0/board/1/project"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testRequestURL(t, r, testAPIEndpoint)
+		fmt.Fprint(w, `{"maxResults":10,"startAt":0,"total":1,"isLast":true,"values":[{"self":"http://www.example.com/jira/rest/api/2/project/EX","id":"10000","key":"EX","name":"Example"}]}`)
+	})
+
+	projects, _, err := testClient.Board.GetProjects(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(projects.Values) != 1 || projects.Values[0].Key != "EX" {
+		t.Errorf("Expected project EX, got %+v", projects.Values)
+	}
+}
+
+func TestBoardService_RankIssuesBefore(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEndpoint := "/rest/agile/1.0/issue/rank"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		testRequestURL(t, r, testAPIEndpoint)
+
+		var payload RankIssuesPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Got error: %v", err)
+		}
+		if payload.RankBeforeIssue != "PR-1" || payload.RankAfterIssue != "" {
+			t.Errorf("Expected rank before PR-1, got %+v", payload)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	result, _, err := testClient.Board.RankIssuesBefore(context.Background(), []string{"PR-2", "PR-3"}, "PR-1")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(result.Entries) != 0 {
+		t.Errorf("Expected no failed entries, got %+v", result.Entries)
+	}
+}
+
+func TestBoardService_RankIssuesAfter_PartialFailure(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEndpoint := "/rest/agile/1.0/issue/rank"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		w.WriteHeader(http.StatusMultiStatus)
+		fmt.Fprint(w, `{"entries":[{"issueId":10000,"issueKey":"PR-2","status":200},{"issueId":10001,"issueKey":"PR-3","status":403,"errors":["Not allowed"]}]}`)
+	})
+
+	result, _, err := testClient.Board.RankIssuesAfter(context.Background(), []string{"PR-2", "PR-3"}, "PR-1")
+	if err == nil {
+		t.Error("Expected an error for the issue which could not be ranked")
+	}
+	if result == nil || len(result.Entries) != 2 {
+		t.Errorf("Expected 2 entries, got %+v", result)
+	}
+}
+
+func TestBoardService_GetBoardConfiguration_EstimationAndRanking(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEndpoint := "/rest/agile/1.0/board/35/configuration"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testRequestURL(t, r, testAPIEndpoint)
+		fmt.Fprint(w, `{"id":35,"name":"Kanban","type":"kanban",
+			"subQuery":{"query":"resolution = EMPTY OR resolution changed after -2w"},
+			"columnConfig":{"columns":[{"name":"To Do","statuses":[{"id":"1"}]}],"constraintType":"issueCount"},
+			"estimation":{"type":"field","field":{"fieldId":"customfield_10002","displayName":"Story Points"}},
+			"ranking":{"rankCustomFieldId":10011}}`)
+	})
+
+	config, _, err := testClient.Board.GetBoardConfiguration(context.Background(), 35)
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	if !config.IsKanban() {
+		t.Error("Expected a kanban board")
+	}
+	if config.Estimation.Field.FieldID != "customfield_10002" || config.Estimation.Field.DisplayName != "Story Points" {
+		t.Errorf("Expected story points estimation, got %+v", config.Estimation)
+	}
+	if config.Ranking.RankCustomFieldID != 10011 {
+		t.Errorf("Expected rank custom field 10011, got %d", config.Ranking.RankCustomFieldID)
+	}
+}
+
+func TestBoardService_GetBoardSwimlanes(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEndpoint := "/rest/greenhopper/1.0/rapidviewconfig/editmodel.json"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testRequestURL(t, r, testAPIEndpoint+"?rapidViewId=35")
+		fmt.Fprint(w, `{"id":35,"name":"Kanban","swimlanesConfig":{"swimlaneStrategy":"custom","canEdit":true,"swimlanes":[
+			{"id":1,"name":"Expedite","query":"priority = Blocker","description":"","isDefault":false},
+			{"id":2,"name":"Everything Else","query":"","description":"","isDefault":true}]}}`)
+	})
+
+	swimlanes, _, err := testClient.Board.GetBoardSwimlanes(context.Background(), 35)
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	if swimlanes.Strategy != "custom" || len(swimlanes.Swimlanes) != 2 {
+		t.Fatalf("Unexpected swimlanes %+v", swimlanes)
+	}
+	if swimlanes.Swimlanes[0].Query != "priority = Blocker" || !swimlanes.Swimlanes[1].IsDefault {
+		t.Errorf("Unexpected swimlanes %+v", swimlanes.Swimlanes)
+	}
+}
+
+func TestBoardService_GetSprintReport(t *testing.T) {
+	setup()
+	defer teardown()
+	testAPIEndpoint := "/rest/greenhopper/1.0/rapid/charts/sprintreport"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testRequestURL(t, r, testAPIEndpoint+"?rapidViewId=35&sprintId=13")
+		fmt.Fprint(w, `{"contents":{"completedIssues":[{"id":10001,"key":"TEST-1","done":true}],
+			"issuesNotCompletedInCurrentSprint":[],"puntedIssues":[{"id":10002,"key":"TEST-2","done":false}],
+			"issueKeysAddedDuringSprint":{"TEST-1":true}},"sprint":{"id":13}}`)
+	})
+
+	report, _, err := testClient.Board.GetSprintReport(context.Background(), 35, 13)
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	if len(report.CompletedIssues) != 1 || len(report.PuntedIssues) != 1 || report.PuntedIssues[0].Key != "TEST-2" {
+		t.Errorf("Unexpected sprint report %+v", report)
+	}
+	if !report.IssueKeysAddedDuringSprint["TEST-1"] {
+		t.Errorf("Expected TEST-1 to be added during the sprint, got %v", report.IssueKeysAddedDuringSprint)
+	}
+}
+
+func testBoardConfiguration(constraintType string) *BoardConfiguration {
+	return &BoardConfiguration{
+		ColumnConfig: BoardConfigurationColumnConfig{
+			ConstraintType: constraintType,
+			Columns: []BoardConfigurationColumn{
+				{Name: "To Do", Status: []BoardConfigurationColumnStatus{{ID: "1"}}, Min: 2},
+				{Name: "In Progress", Status: []BoardConfigurationColumnStatus{{ID: "3"}, {ID: "4"}}, Max: 2},
+				{Name: "Done", Status: []BoardConfigurationColumnStatus{{ID: "6"}}},
+			},
+		},
+	}
+}
+
+func testBoardIssue(statusID string, subtask bool) Issue {
+	return Issue{Fields: &IssueFields{Status: &Status{ID: statusID}, Type: IssueType{Subtask: subtask}}}
+}
+
+func TestBoardConfiguration_ColumnForStatus(t *testing.T) {
+	config := testBoardConfiguration("issueCount")
+
+	if column := config.ColumnForStatus("4"); column == nil || column.Name != "In Progress" {
+		t.Errorf("Expected status 4 to map to In Progress, got %+v", column)
+	}
+	if column := config.ColumnForStatus("99"); column != nil {
+		t.Errorf("Expected unmapped status, got %+v", column)
+	}
+}
+
+func TestBoardConfiguration_WIPViolations(t *testing.T) {
+	issues := []Issue{
+		testBoardIssue("1", false),
+		testBoardIssue("3", false),
+		testBoardIssue("4", false),
+		testBoardIssue("4", true),
+		testBoardIssue("6", false),
+		testBoardIssue("99", false),
+	}
+
+	violations := testBoardConfiguration("issueCount").WIPViolations(issues)
+	if len(violations) != 2 {
+		t.Fatalf("Expected 2 violations, got %+v", violations)
+	}
+	if violations[0].Column.Name != "To Do" || !violations[0].BelowMin || violations[0].Count != 1 {
+		t.Errorf("Expected To Do below its minimum, got %+v", violations[0])
+	}
+	if violations[1].Column.Name != "In Progress" || !violations[1].AboveMax || violations[1].Count != 3 {
+		t.Errorf("Expected In Progress above its maximum, got %+v", violations[1])
+	}
+
+	wip := testBoardConfiguration("issueCountExclSubs").ColumnWIP(issues)
+	if wip[1].Count != 2 || wip[1].AboveMax {
+		t.Errorf("Expected sub-tasks not to be counted, got %+v", wip[1])
+	}
+
+	if violations := testBoardConfiguration("none").WIPViolations(issues); len(violations) != 0 {
+		t.Errorf("Expected no violations without constraints, got %+v", violations)
+	}
+}
@@ -0,0 +1,422 @@
+package jira
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTLs are the TTLs of the reference data endpoints which rarely change,
+// used by Client.EnableCache if CacheOptions.TTLs is nil.
+var DefaultCacheTTLs = map[string]time.Duration{
+	"rest/api/2/field":          time.Hour,
+	"rest/api/2/priority":       time.Hour,
+	"rest/api/2/resolution":     time.Hour,
+	"rest/api/2/status":         time.Hour,
+	"rest/api/2/statuscategory": time.Hour,
+	"rest/api/2/issuetype":      time.Hour,
+	"rest/api/2/issueLinkType":  time.Hour,
+	"rest/api/2/serverInfo":     time.Hour,
+	"rest/api/2/configuration":  time.Hour,
+}
+
+// CachedResponse is a successful response to a GET request stored in a CacheStore.
+type CachedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	// ETag is sent as If-None-Match to revalidate the response once it expired.
+	ETag    string    `json:"etag,omitempty"`
+	Expires time.Time `json:"expires"`
+}
+
+// CacheStore stores cached responses by key. Keys are endpoints relative to the base URL
+// including the query, like "rest/api/2/field". Implementations must be safe for concurrent use.
+type CacheStore interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, resp *CachedResponse)
+	Delete(key string)
+	// DeletePrefix deletes all responses whose key starts with prefix, or all responses if prefix is empty.
+	DeletePrefix(prefix string)
+}
+
+// CacheOptions configures the response cache of a Client, see Client.EnableCache.
+type CacheOptions struct {
+	// Store holds the cached responses. Defaults to an LRUCache of 1000 responses.
+	Store CacheStore
+	// TTLs are the TTLs of endpoints relative to the base URL, like "rest/api/2/field".
+	// An endpoint applies to itself and all endpoints below it; the longest matching endpoint wins.
+	// Defaults to DefaultCacheTTLs.
+	TTLs map[string]time.Duration
+	// DefaultTTL is the TTL of GET requests to all other endpoints. Defaults to 0, which does not cache them.
+	// Attachment and thumbnail downloads and requests with a Range header are never cached.
+	DefaultTTL time.Duration
+}
+
+// responseCache is the cache configuration of a Client
+type responseCache struct {
+	store      CacheStore
+	ttls       map[string]time.Duration
+	defaultTTL time.Duration
+}
+
+// EnableCache caches the responses of GET requests to the endpoints with a TTL.
+// Expired responses with an ETag are revalidated with a conditional request. Successful requests with
+// other methods invalidate the cached responses of their endpoint, so updates through this client are seen
+// immediately; changes by others are seen once the TTL expired.
+// The cache does not distinguish users, so a client must not be shared by several users while caching.
+func (c *Client) EnableCache(options CacheOptions) {
+	cache := &responseCache{store: options.Store, defaultTTL: options.DefaultTTL}
+	if cache.store == nil {
+		cache.store = NewLRUCache(1000)
+	}
+	ttls := options.TTLs
+	if ttls == nil {
+		ttls = DefaultCacheTTLs
+	}
+	// Copy the TTLs, so later changes to the map, e.g. of DefaultCacheTTLs, do not race with requests
+	cache.ttls = make(map[string]time.Duration, len(ttls))
+	for endpoint, ttl := range ttls {
+		cache.ttls[endpoint] = ttl
+	}
+
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	c.cache = cache
+}
+
+// DisableCache stops caching responses. The store is left untouched.
+func (c *Client) DisableCache() {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	c.cache = nil
+}
+
+// InvalidateCache removes the cached responses of the endpoint relative to the base URL and all endpoints below it,
+// e.g. "rest/api/2/field", or all cached responses if endpoint is empty.
+func (c *Client) InvalidateCache(endpoint string) {
+	cache := c.responseCache()
+	if cache == nil {
+		return
+	}
+	endpoint = strings.TrimLeft(endpoint, "/")
+	if endpoint == "" {
+		cache.store.DeletePrefix("")
+		return
+	}
+	// Invalidate the endpoint with any query, but not endpoints which merely share the prefix
+	cache.store.DeletePrefix(endpoint + "?")
+	cache.store.DeletePrefix(endpoint + "/")
+	cache.store.Delete(endpoint)
+}
+
+func (c *Client) responseCache() *responseCache {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	return c.cache
+}
+
+// endpoint returns the path of the request relative to the base URL
+func (c *Client) endpoint(req *http.Request) string {
+	path := strings.TrimPrefix(req.URL.Path, c.BaseURL.Path)
+	return strings.TrimLeft(path, "/")
+}
+
+// uncachedEndpoints are never cached, so attachments are streamed instead of being buffered in memory
+var uncachedEndpoints = []string{"secure/attachment/", "secure/thumbnail/"}
+
+// ttl returns the TTL of the endpoint
+func (r *responseCache) ttl(endpoint string) time.Duration {
+	for _, prefix := range uncachedEndpoints {
+		if strings.HasPrefix(endpoint, prefix) {
+			return 0
+		}
+	}
+	ttl, longest := r.defaultTTL, -1
+	for prefix, t := range r.ttls {
+		if (endpoint == prefix || strings.HasPrefix(endpoint, prefix+"/")) && len(prefix) > longest {
+			ttl, longest = t, len(prefix)
+		}
+	}
+	return ttl
+}
+
+// doCached performs the request, serving GET requests from the cache if possible.
+// fromCache reports whether the response was served from the cache without a request to Jira.
+func (c *Client) doCached(req *http.Request) (httpResp *http.Response, fromCache bool, err error) {
+	cache := c.responseCache()
+	if cache == nil || req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		httpResp, err = c.client.Do(req)
+		return httpResp, false, err
+	}
+	endpoint := c.endpoint(req)
+	ttl := cache.ttl(endpoint)
+	if ttl <= 0 {
+		httpResp, err = c.client.Do(req)
+		return httpResp, false, err
+	}
+
+	key := endpoint
+	if req.URL.RawQuery != "" {
+		key += "?" + req.URL.RawQuery
+	}
+	cached, ok := cache.store.Get(key)
+	if ok && time.Now().Before(cached.Expires) {
+		return cached.response(req), true, nil
+	}
+	if ok && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	httpResp, err = c.client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if ok && httpResp.StatusCode == http.StatusNotModified {
+		httpResp.Body.Close()
+		cached.Expires = time.Now().Add(ttl)
+		cache.store.Set(key, cached)
+		return cached.response(req), true, nil
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return httpResp, false, nil
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	httpResp.Body.Close()
+	if err != nil {
+		return nil, false, err
+	}
+	httpResp.Body = io.NopCloser(bytes.NewReader(body))
+	cache.store.Set(key, &CachedResponse{
+		StatusCode: httpResp.StatusCode,
+		Header:     httpResp.Header.Clone(),
+		Body:       body,
+		ETag:       httpResp.Header.Get("ETag"),
+		Expires:    time.Now().Add(ttl),
+	})
+	return httpResp, false, nil
+}
+
+// invalidateAfterWrite drops the cached responses of the endpoint of a successful write request,
+// the endpoints below it and the collections above it, e.g. rest/api/2/priority for rest/api/2/priority/1
+func (c *Client) invalidateAfterWrite(req *http.Request) {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return
+	}
+	cache := c.responseCache()
+	if cache == nil {
+		return
+	}
+	endpoint := c.endpoint(req)
+	c.InvalidateCache(endpoint)
+	for i := strings.LastIndex(endpoint, "/"); i > 0; i = strings.LastIndex(endpoint, "/") {
+		endpoint = endpoint[:i]
+		cache.store.Delete(endpoint)
+		cache.store.DeletePrefix(endpoint + "?")
+	}
+}
+
+func (r *CachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(r.StatusCode),
+		StatusCode:    r.StatusCode,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// LRUCache is an in-memory CacheStore which evicts the least recently used responses.
+type LRUCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+type lruEntry struct {
+	key  string
+	resp *CachedResponse
+}
+
+// NewLRUCache creates an LRUCache holding at most maxEntries responses, or 1000 if maxEntries is not positive.
+func NewLRUCache(maxEntries int) *LRUCache {
+	if maxEntries <= 0 {
+		maxEntries = 1000
+	}
+	return &LRUCache{maxEntries: maxEntries, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+// Get returns the response stored under key.
+func (l *LRUCache) Get(key string) (*CachedResponse, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+	l.order.MoveToFront(e)
+	resp := *e.Value.(*lruEntry).resp
+	return &resp, true
+}
+
+// Set stores the response under key, evicting the least recently used response if the cache is full.
+func (l *LRUCache) Set(key string, resp *CachedResponse) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	stored := *resp
+	if e, ok := l.entries[key]; ok {
+		e.Value.(*lruEntry).resp = &stored
+		l.order.MoveToFront(e)
+		return
+	}
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, resp: &stored})
+	for l.order.Len() > l.maxEntries {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Delete deletes the response stored under key.
+func (l *LRUCache) Delete(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e, ok := l.entries[key]; ok {
+		l.order.Remove(e)
+		delete(l.entries, key)
+	}
+}
+
+// DeletePrefix deletes all responses whose key starts with prefix.
+func (l *LRUCache) DeletePrefix(prefix string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for key, e := range l.entries {
+		if strings.HasPrefix(key, prefix) {
+			l.order.Remove(e)
+			delete(l.entries, key)
+		}
+	}
+}
+
+// Len returns the number of stored responses.
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+// FileCache is a CacheStore keeping every response in a JSON file in a directory,
+// so cached reference data survives restarts of short-lived tools.
+type FileCache struct {
+	mu  sync.Mutex
+	dir string
+}
+
+type fileCacheEntry struct {
+	Key      string          `json:"key"`
+	Response *CachedResponse `json:"response"`
+}
+
+// NewFileCache creates a FileCache in dir, creating the directory if needed.
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+func (f *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the response stored under key. Unreadable files are treated as missing.
+func (f *FileCache) Get(key string) (*CachedResponse, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entry, err := readFileCacheEntry(f.path(key))
+	if err != nil || entry.Key != key {
+		return nil, false
+	}
+	return entry.Response, true
+}
+
+// Set stores the response under key. Write errors are ignored, as the response can be fetched again.
+func (f *FileCache) Set(key string, resp *CachedResponse) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, err := json.Marshal(fileCacheEntry{Key: key, Response: resp})
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(f.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(b)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), f.path(key)); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// Delete deletes the response stored under key.
+func (f *FileCache) Delete(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	os.Remove(f.path(key))
+}
+
+// DeletePrefix deletes all responses whose key starts with prefix.
+func (f *FileCache) DeletePrefix(prefix string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	files, err := filepath.Glob(filepath.Join(f.dir, "*.json"))
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		if prefix != "" {
+			entry, err := readFileCacheEntry(file)
+			if err == nil && !strings.HasPrefix(entry.Key, prefix) {
+				continue
+			}
+		}
+		os.Remove(file)
+	}
+}
+
+func readFileCacheEntry(path string) (*fileCacheEntry, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	entry := new(fileCacheEntry)
+	if err := json.Unmarshal(b, entry); err != nil {
+		return nil, err
+	}
+	if entry.Response == nil {
+		return nil, os.ErrNotExist
+	}
+	return entry, nil
+}
@@ -0,0 +1,238 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_EnableCache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	testMux.HandleFunc("/rest/api/2/priority", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		requests++
+		fmt.Fprint(w, `[{"id":"1","name":"High"}]`)
+	})
+	testClient.EnableCache(CacheOptions{})
+
+	for i := 0; i < 2; i++ {
+		priorities, resp, err := testClient.Priority.GetList(context.Background())
+		if err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+		if len(priorities) != 1 || priorities[0].Name != "High" {
+			t.Errorf("Unexpected priorities %+v", priorities)
+		}
+		if resp.FromCache != (i == 1) {
+			t.Errorf("Expected FromCache %v on request %d", i == 1, i+1)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request, got %d", requests)
+	}
+
+	testClient.InvalidateCache("rest/api/2/priority")
+	if _, _, err := testClient.Priority.GetList(context.Background()); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected a request after invalidation, got %d requests", requests)
+	}
+
+	testClient.DisableCache()
+	if _, _, err := testClient.Priority.GetList(context.Background()); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if requests != 3 {
+		t.Errorf("Expected a request with disabled cache, got %d requests", requests)
+	}
+}
+
+func TestClient_EnableCache_CopiesTTLs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ttls := map[string]time.Duration{"rest/api/2/field": time.Minute}
+	testClient.EnableCache(CacheOptions{TTLs: ttls})
+	ttls["rest/api/2/field"] = 0
+	if ttl := testClient.responseCache().ttls["rest/api/2/field"]; ttl != time.Minute {
+		t.Errorf("Expected the TTLs to be copied, got %s", ttl)
+	}
+
+	testClient.EnableCache(CacheOptions{})
+	testClient.responseCache().ttls["rest/api/2/field"] = 0
+	if DefaultCacheTTLs["rest/api/2/field"] != time.Hour {
+		t.Error("Expected DefaultCacheTTLs to be unchanged")
+	}
+}
+
+func TestClient_EnableCache_ETag(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests, notModified := 0, 0
+	testMux.HandleFunc("/rest/api/2/field", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `[{"id":"summary","name":"Summary"}]`)
+	})
+	testClient.EnableCache(CacheOptions{TTLs: map[string]time.Duration{"rest/api/2/field": time.Nanosecond}})
+
+	for i := 0; i < 2; i++ {
+		time.Sleep(time.Millisecond)
+		fields, resp, err := testClient.Field.GetList(context.Background())
+		if err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+		if len(fields) != 1 || fields[0].ID != "summary" {
+			t.Errorf("Unexpected fields %+v", fields)
+		}
+		if resp.FromCache != (i == 1) {
+			t.Errorf("Expected FromCache %v on request %d", i == 1, i+1)
+		}
+	}
+	if requests != 2 || notModified != 1 {
+		t.Errorf("Expected a revalidation, got %d requests and %d not modified", requests, notModified)
+	}
+}
+
+func TestClient_EnableCache_InvalidateOnWrite(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	testMux.HandleFunc("/rest/api/2/issueLinkType", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			requests++
+			fmt.Fprint(w, `[{"id":"10000","name":"Blocks"}]`)
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":"10001","name":"Duplicate"}`)
+		}
+	})
+	testMux.HandleFunc("/rest/api/2/resolution", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":"1","name":"Done"}]`)
+	})
+	store := NewLRUCache(10)
+	testClient.EnableCache(CacheOptions{Store: store})
+
+	testClient.IssueLinkType.GetList(context.Background())
+	testClient.Resolution.GetList(context.Background())
+	if _, _, err := testClient.IssueLinkType.Create(context.Background(), &IssueLinkType{Name: "Duplicate"}); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	testClient.IssueLinkType.GetList(context.Background())
+
+	if requests != 2 {
+		t.Errorf("Expected the write to invalidate the link types, got %d requests", requests)
+	}
+	if _, ok := store.Get("rest/api/2/resolution"); !ok {
+		t.Error("Expected the resolutions to stay cached")
+	}
+
+	testMux.HandleFunc("/rest/api/2/issueLinkType/10000", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	if _, err := testClient.IssueLinkType.Delete(context.Background(), "10000"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if _, ok := store.Get("rest/api/2/issueLinkType"); ok {
+		t.Error("Expected a write to a link type to invalidate the list of link types")
+	}
+}
+
+func TestClient_EnableCache_Attachments(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	testMux.HandleFunc("/secure/attachment/10000/", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, "content")
+	})
+	testMux.HandleFunc("/rest/api/2/issue/TEST-1", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"key":"TEST-1"}`)
+	})
+	testClient.EnableCache(CacheOptions{DefaultTTL: time.Hour})
+
+	var buf bytes.Buffer
+	for i := 0; i < 2; i++ {
+		if _, _, err := testClient.Issue.DownloadAttachmentTo(context.Background(), "10000", &buf, nil); err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("Expected attachment downloads not to be cached, got %d requests", requests)
+	}
+
+	req, _ := testClient.NewRequest(context.Background(), http.MethodGet, "rest/api/2/issue/TEST-1", nil)
+	req.Header.Set("Range", "bytes=0-1")
+	for i := 0; i < 2; i++ {
+		resp, err := testClient.Do(req, nil)
+		if err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+		resp.Body.Close()
+	}
+	if requests != 4 {
+		t.Errorf("Expected requests with a Range header not to be cached, got %d requests", requests)
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	cache := NewLRUCache(2)
+	cache.Set("a", &CachedResponse{Body: []byte("a")})
+	cache.Set("b", &CachedResponse{Body: []byte("b")})
+	cache.Get("a")
+	cache.Set("c", &CachedResponse{Body: []byte("c")})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Expected the least recently used entry to be evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Error("Expected a to be cached")
+	}
+
+	cache.Set("c/1", &CachedResponse{})
+	cache.DeletePrefix("c")
+	if cache.Len() != 1 {
+		t.Errorf("Expected 1 entry, got %d", cache.Len())
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	cache, err := NewFileCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+	cache.Set("rest/api/2/status", &CachedResponse{StatusCode: 200, Body: []byte(`[]`), ETag: `"x"`, Expires: expires})
+	cache.Set("rest/api/2/status/1", &CachedResponse{StatusCode: 200})
+	cache.Set("rest/api/2/priority", &CachedResponse{StatusCode: 200})
+
+	got, ok := cache.Get("rest/api/2/status")
+	if !ok || string(got.Body) != `[]` || got.ETag != `"x"` || !got.Expires.Equal(expires) {
+		t.Errorf("Unexpected cached response %+v", got)
+	}
+
+	cache.DeletePrefix("rest/api/2/status")
+	if _, ok := cache.Get("rest/api/2/status/1"); ok {
+		t.Error("Expected rest/api/2/status/1 to be deleted")
+	}
+	if _, ok := cache.Get("rest/api/2/priority"); !ok {
+		t.Error("Expected rest/api/2/priority to stay cached")
+	}
+}
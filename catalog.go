@@ -0,0 +1,394 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Catalog is a snapshot of the reference data of a Jira instance: fields, statuses, priorities, resolutions,
+// issue types, issue link types and projects. It translates between names and IDs without requests to Jira
+// and can be saved to disk, so tools do not need to load it on every run.
+//
+// Names are matched case-insensitively. IDs, keys and names are all accepted wherever an entity is resolved.
+type Catalog struct {
+	Fields      []Field          `json:"fields"`
+	Statuses    []Status         `json:"statuses"`
+	Priorities  []Priority       `json:"priorities"`
+	Resolutions []Resolution     `json:"resolutions"`
+	IssueTypes  []IssueType      `json:"issueTypes"`
+	LinkTypes   []IssueLinkType  `json:"linkTypes"`
+	Projects    []CatalogProject `json:"projects"`
+	// LoadedAt is the time the catalog was loaded from Jira.
+	LoadedAt time.Time `json:"loadedAt"`
+}
+
+// CatalogProject is a project in a Catalog.
+type CatalogProject struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// LoadCatalog loads the reference data of the Jira instance.
+func LoadCatalog(ctx context.Context, client *Client) (*Catalog, error) {
+	c := &Catalog{LoadedAt: time.Now()}
+	var err error
+	if c.Fields, _, err = client.Field.GetList(ctx); err != nil {
+		return nil, fmt.Errorf("loading fields: %w", err)
+	}
+	if c.Statuses, _, err = client.Status.GetAllStatuses(ctx); err != nil {
+		return nil, fmt.Errorf("loading statuses: %w", err)
+	}
+	if c.Priorities, _, err = client.Priority.GetList(ctx); err != nil {
+		return nil, fmt.Errorf("loading priorities: %w", err)
+	}
+	if c.Resolutions, _, err = client.Resolution.GetList(ctx); err != nil {
+		return nil, fmt.Errorf("loading resolutions: %w", err)
+	}
+	if c.IssueTypes, err = getAllIssueTypes(ctx, client); err != nil {
+		return nil, fmt.Errorf("loading issue types: %w", err)
+	}
+	if c.LinkTypes, _, err = client.IssueLinkType.GetList(ctx); err != nil {
+		return nil, fmt.Errorf("loading issue link types: %w", err)
+	}
+	projects, _, err := client.Project.GetAll(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("loading projects: %w", err)
+	}
+	for _, p := range *projects {
+		c.Projects = append(c.Projects, CatalogProject{ID: p.ID, Key: p.Key, Name: p.Name})
+	}
+	return c, nil
+}
+
+// getAllIssueTypes returns all issue types visible to the user
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-issue-types/#api-rest-api-2-issuetype-get
+func getAllIssueTypes(ctx context.Context, client *Client) ([]IssueType, error) {
+	req, err := client.NewRequest(ctx, http.MethodGet, "rest/api/2/issuetype", nil)
+	if err != nil {
+		return nil, err
+	}
+	var issueTypes []IssueType
+	resp, err := client.Do(req, &issueTypes)
+	if err != nil {
+		return nil, NewJiraError(resp, err)
+	}
+	return issueTypes, nil
+}
+
+// ReadCatalog reads a catalog written by Catalog.Save.
+func ReadCatalog(r io.Reader) (*Catalog, error) {
+	c := new(Catalog)
+	if err := json.NewDecoder(r).Decode(c); err != nil {
+		return nil, fmt.Errorf("decoding catalog: %w", err)
+	}
+	return c, nil
+}
+
+// LoadCatalogFile reads a catalog written by Catalog.SaveFile.
+func LoadCatalogFile(path string) (*Catalog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadCatalog(f)
+}
+
+// Save writes the catalog as JSON.
+func (c *Catalog) Save(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c)
+}
+
+// SaveFile writes the catalog to the file at path. The file is replaced atomically,
+// so concurrent readers never see a partially written catalog.
+func (c *Catalog) SaveFile(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".catalog-*")
+	if err != nil {
+		return err
+	}
+	err = c.Save(tmp)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// Age returns the time since the catalog was loaded from Jira.
+func (c *Catalog) Age() time.Duration {
+	return time.Since(c.LoadedAt)
+}
+
+// catalogFind returns the index of the only entity with the ID or one of the names.
+// IDs take precedence over names, so an entity named like the ID of another one can still be resolved by ID.
+func catalogFind(kind, idOrName string, n int, id func(int) string, names func(int) []string) (int, error) {
+	for i := 0; i < n; i++ {
+		if id(i) == idOrName {
+			return i, nil
+		}
+	}
+	found := -1
+	var ids []string
+	for i := 0; i < n; i++ {
+		for _, name := range names(i) {
+			if name != "" && strings.EqualFold(name, idOrName) {
+				if found < 0 {
+					found = i
+				}
+				ids = append(ids, id(i))
+				break
+			}
+		}
+	}
+	switch {
+	case found < 0:
+		return -1, fmt.Errorf("unknown %s %q", kind, idOrName)
+	case len(ids) > 1:
+		return -1, fmt.Errorf("%s name %q is ambiguous, use one of the IDs %s", kind, idOrName, strings.Join(ids, ", "))
+	}
+	return found, nil
+}
+
+// ResolveField returns the field with the ID, key or name, e.g. "customfield_10010" or "Story Points".
+func (c *Catalog) ResolveField(idOrName string) (*Field, error) {
+	i, err := catalogFind("field", idOrName, len(c.Fields),
+		func(i int) string { return c.Fields[i].ID },
+		func(i int) []string { return []string{c.Fields[i].Key, c.Fields[i].Name} })
+	if err != nil {
+		return nil, err
+	}
+	return &c.Fields[i], nil
+}
+
+// FieldID returns the ID of the field with the ID, key or name.
+func (c *Catalog) FieldID(idOrName string) (string, error) {
+	f, err := c.ResolveField(idOrName)
+	if err != nil {
+		return "", err
+	}
+	return f.ID, nil
+}
+
+// ResolveStatus returns the status with the ID or name.
+func (c *Catalog) ResolveStatus(idOrName string) (*Status, error) {
+	i, err := catalogFind("status", idOrName, len(c.Statuses),
+		func(i int) string { return c.Statuses[i].ID },
+		func(i int) []string { return []string{c.Statuses[i].Name} })
+	if err != nil {
+		return nil, err
+	}
+	return &c.Statuses[i], nil
+}
+
+// ResolvePriority returns the priority with the ID or name.
+func (c *Catalog) ResolvePriority(idOrName string) (*Priority, error) {
+	i, err := catalogFind("priority", idOrName, len(c.Priorities),
+		func(i int) string { return c.Priorities[i].ID },
+		func(i int) []string { return []string{c.Priorities[i].Name} })
+	if err != nil {
+		return nil, err
+	}
+	return &c.Priorities[i], nil
+}
+
+// ResolveResolution returns the resolution with the ID or name.
+func (c *Catalog) ResolveResolution(idOrName string) (*Resolution, error) {
+	i, err := catalogFind("resolution", idOrName, len(c.Resolutions),
+		func(i int) string { return c.Resolutions[i].ID },
+		func(i int) []string { return []string{c.Resolutions[i].Name} })
+	if err != nil {
+		return nil, err
+	}
+	return &c.Resolutions[i], nil
+}
+
+// ResolveIssueType returns the issue type with the ID or name.
+// Team-managed projects have their own issue types, so a name may be ambiguous.
+func (c *Catalog) ResolveIssueType(idOrName string) (*IssueType, error) {
+	i, err := catalogFind("issue type", idOrName, len(c.IssueTypes),
+		func(i int) string { return c.IssueTypes[i].ID },
+		func(i int) []string { return []string{c.IssueTypes[i].Name} })
+	if err != nil {
+		return nil, err
+	}
+	return &c.IssueTypes[i], nil
+}
+
+// ResolveLinkType returns the issue link type with the ID, name, inward or outward description,
+// e.g. "Blocks" or "is blocked by".
+func (c *Catalog) ResolveLinkType(idOrName string) (*IssueLinkType, error) {
+	i, err := catalogFind("issue link type", idOrName, len(c.LinkTypes),
+		func(i int) string { return c.LinkTypes[i].ID },
+		func(i int) []string {
+			return []string{c.LinkTypes[i].Name, c.LinkTypes[i].Inward, c.LinkTypes[i].Outward}
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &c.LinkTypes[i], nil
+}
+
+// ResolveProject returns the project with the ID, key or name.
+func (c *Catalog) ResolveProject(idOrKey string) (*CatalogProject, error) {
+	i, err := catalogFind("project", idOrKey, len(c.Projects),
+		func(i int) string { return c.Projects[i].ID },
+		func(i int) []string { return []string{c.Projects[i].Key, c.Projects[i].Name} })
+	if err != nil {
+		return nil, err
+	}
+	return &c.Projects[i], nil
+}
+
+// JQLField returns the name of the field to use in JQL clauses, e.g. "cf[10010]" for the custom field
+// "Story Points" and "fixVersion" for "Fix Version/s". Quote the result with QuoteJQL if needed.
+func (c *Catalog) JQLField(idOrName string) (string, error) {
+	f, err := c.ResolveField(idOrName)
+	if err != nil {
+		return "", err
+	}
+	if f.Custom {
+		if f.Schema.CustomID != 0 {
+			return fmt.Sprintf("cf[%d]", f.Schema.CustomID), nil
+		}
+		return hierarchyClause(f.ID, f.Name), nil
+	}
+	if !f.Searchable && len(f.ClauseNames) == 0 {
+		return "", fmt.Errorf("field %q is not searchable", f.Name)
+	}
+	for _, name := range f.ClauseNames {
+		if !strings.HasPrefix(name, "cf[") {
+			return name, nil
+		}
+	}
+	if len(f.ClauseNames) > 0 {
+		return f.ClauseNames[0], nil
+	}
+	return f.ID, nil
+}
+
+// QuoteJQL quotes a JQL value like a status name or user, escaping quotes and backslashes.
+func QuoteJQL(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// ResolveFields translates the keys of fields from field names to field IDs, for use in
+// IssueFields.Unknowns or an edit request. Keys which are already field IDs are kept.
+func (c *Catalog) ResolveFields(fields map[string]interface{}) (map[string]interface{}, error) {
+	resolved := make(map[string]interface{}, len(fields))
+	for name, value := range fields {
+		id, err := c.FieldID(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := resolved[id]; ok {
+			return nil, fmt.Errorf("field %s is set twice", id)
+		}
+		resolved[id] = value
+	}
+	return resolved, nil
+}
+
+// ResolveIssueFields fills in the IDs of the project, issue type, priority, resolution and status of fields
+// which are only given by key or name, so the fields can be used to create an issue.
+func (c *Catalog) ResolveIssueFields(fields *IssueFields) error {
+	if fields.Project.ID == "" && (fields.Project.Key != "" || fields.Project.Name != "") {
+		ref := fields.Project.Key
+		if ref == "" {
+			ref = fields.Project.Name
+		}
+		p, err := c.ResolveProject(ref)
+		if err != nil {
+			return err
+		}
+		fields.Project.ID = p.ID
+	}
+	if fields.Type.ID == "" && fields.Type.Name != "" {
+		t, err := c.ResolveIssueType(fields.Type.Name)
+		if err != nil {
+			return err
+		}
+		fields.Type.ID = t.ID
+	}
+	if fields.Priority != nil && fields.Priority.ID == "" && fields.Priority.Name != "" {
+		p, err := c.ResolvePriority(fields.Priority.Name)
+		if err != nil {
+			return err
+		}
+		fields.Priority.ID = p.ID
+	}
+	if fields.Resolution != nil && fields.Resolution.ID == "" && fields.Resolution.Name != "" {
+		r, err := c.ResolveResolution(fields.Resolution.Name)
+		if err != nil {
+			return err
+		}
+		fields.Resolution.ID = r.ID
+	}
+	if fields.Status != nil && fields.Status.ID == "" && fields.Status.Name != "" {
+		s, err := c.ResolveStatus(fields.Status.Name)
+		if err != nil {
+			return err
+		}
+		fields.Status.ID = s.ID
+	}
+	return nil
+}
+
+// CustomField returns the raw value of the custom field with the ID or name of the issue.
+// The boolean is false if the issue does not have the field.
+func (c *Catalog) CustomField(issue *Issue, idOrName string) (interface{}, bool, error) {
+	id, err := c.FieldID(idOrName)
+	if err != nil {
+		return nil, false, err
+	}
+	if issue.Fields == nil {
+		return nil, false, nil
+	}
+	value, ok := issue.Fields.Unknowns[id]
+	return value, ok, nil
+}
+
+// SetCustomField sets the custom field with the ID or name of the issue.
+func (c *Catalog) SetCustomField(issue *Issue, idOrName string, value interface{}) error {
+	id, err := c.FieldID(idOrName)
+	if err != nil {
+		return err
+	}
+	if issue.Fields == nil {
+		issue.Fields = &IssueFields{}
+	}
+	if issue.Fields.Unknowns == nil {
+		issue.Fields.Unknowns = map[string]interface{}{}
+	}
+	issue.Fields.Unknowns[id] = value
+	return nil
+}
+
+// CustomFieldsByName returns the custom fields returned by IssueService.GetCustomFields keyed by field name.
+// Fields unknown to the catalog keep their ID.
+func (c *Catalog) CustomFieldsByName(fields CustomFields) CustomFields {
+	byName := make(CustomFields, len(fields))
+	for id, value := range fields {
+		name := id
+		if f, err := c.ResolveField(id); err == nil && f.ID == id {
+			name = f.Name
+		}
+		byName[name] = value
+	}
+	return byName
+}
@@ -0,0 +1,168 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testCatalog() *Catalog {
+	return &Catalog{
+		Fields: []Field{
+			{ID: "summary", Key: "summary", Name: "Summary", Searchable: true, ClauseNames: []string{"summary"}},
+			{ID: "fixVersions", Key: "fixVersions", Name: "Fix Version/s", Searchable: true, ClauseNames: []string{"fixVersion"}},
+			{ID: "customfield_10010", Key: "customfield_10010", Name: "Story Points", Custom: true, Schema: FieldSchema{CustomID: 10010}},
+			{ID: "customfield_10020", Name: "Team", Custom: true},
+			{ID: "customfield_10021", Name: "Team", Custom: true},
+		},
+		Statuses:    []Status{{ID: "1", Name: "Open"}, {ID: "3", Name: "In Progress"}},
+		Priorities:  []Priority{{ID: "2", Name: "High"}},
+		Resolutions: []Resolution{{ID: "10000", Name: "Done"}},
+		IssueTypes:  []IssueType{{ID: "10001", Name: "Task"}},
+		LinkTypes:   []IssueLinkType{{ID: "10000", Name: "Blocks", Inward: "is blocked by", Outward: "blocks"}},
+		Projects:    []CatalogProject{{ID: "10100", Key: "OPS", Name: "Operations"}},
+	}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	setup()
+	defer teardown()
+
+	responses := map[string]string{
+		"/rest/api/2/field":         `[{"id":"customfield_10010","name":"Story Points","custom":true}]`,
+		"/rest/api/2/status":        `[{"id":"1","name":"Open"}]`,
+		"/rest/api/2/priority":      `[{"id":"2","name":"High"}]`,
+		"/rest/api/2/resolution":    `[{"id":"10000","name":"Done"}]`,
+		"/rest/api/2/issuetype":     `[{"id":"10001","name":"Task"}]`,
+		"/rest/api/2/issueLinkType": `[{"id":"10000","name":"Blocks"}]`,
+		"/rest/api/2/project":       `[{"id":"10100","key":"OPS","name":"Operations"}]`,
+	}
+	for path, body := range responses {
+		body := body
+		testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodGet)
+			fmt.Fprint(w, body)
+		})
+	}
+
+	catalog, err := LoadCatalog(context.Background(), testClient)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(catalog.Fields) != 1 || len(catalog.Statuses) != 1 || len(catalog.Priorities) != 1 || len(catalog.Resolutions) != 1 ||
+		len(catalog.IssueTypes) != 1 || len(catalog.Projects) != 1 || catalog.LoadedAt.IsZero() {
+		t.Errorf("Unexpected catalog %+v", catalog)
+	}
+	if id, err := catalog.FieldID("story points"); err != nil || id != "customfield_10010" {
+		t.Errorf("Expected customfield_10010, got %q, %v", id, err)
+	}
+}
+
+func TestCatalog_SaveFile(t *testing.T) {
+	catalog := testCatalog()
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	if err := catalog.SaveFile(path); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	loaded, err := LoadCatalogFile(path)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if !reflect.DeepEqual(loaded.Fields, catalog.Fields) || !reflect.DeepEqual(loaded.Projects, catalog.Projects) {
+		t.Errorf("Expected %+v, got %+v", catalog, loaded)
+	}
+
+	if _, err := ReadCatalog(bytes.NewBufferString("{")); err == nil {
+		t.Error("Expected an error for a truncated catalog")
+	}
+}
+
+func TestCatalog_Resolve(t *testing.T) {
+	catalog := testCatalog()
+
+	if s, err := catalog.ResolveStatus("in progress"); err != nil || s.ID != "3" {
+		t.Errorf("Expected status 3, got %+v, %v", s, err)
+	}
+	if s, err := catalog.ResolveStatus("1"); err != nil || s.Name != "Open" {
+		t.Errorf("Expected status Open, got %+v, %v", s, err)
+	}
+	if p, err := catalog.ResolveProject("OPS"); err != nil || p.ID != "10100" {
+		t.Errorf("Expected project 10100, got %+v, %v", p, err)
+	}
+	if l, err := catalog.ResolveLinkType("is blocked by"); err != nil || l.Name != "Blocks" {
+		t.Errorf("Expected link type Blocks, got %+v, %v", l, err)
+	}
+	if _, err := catalog.ResolvePriority("Lowest"); err == nil || err.Error() != `unknown priority "Lowest"` {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if _, err := catalog.FieldID("Team"); err == nil || !strings.Contains(err.Error(), "customfield_10020, customfield_10021") {
+		t.Errorf("Expected an ambiguity error, got %v", err)
+	}
+	if id, err := catalog.FieldID("customfield_10021"); err != nil || id != "customfield_10021" {
+		t.Errorf("Expected customfield_10021, got %q, %v", id, err)
+	}
+}
+
+func TestCatalog_JQLField(t *testing.T) {
+	catalog := testCatalog()
+	for name, want := range map[string]string{
+		"Story Points":      "cf[10010]",
+		"customfield_10020": "cf[10020]",
+		"Fix Version/s":     "fixVersion",
+		"summary":           "summary",
+	} {
+		if got, err := catalog.JQLField(name); err != nil || got != want {
+			t.Errorf("Expected %s for %s, got %q, %v", want, name, got, err)
+		}
+	}
+	if got := QuoteJQL(`say "hi"`); got != `"say \"hi\""` {
+		t.Errorf("Unexpected quoted value %s", got)
+	}
+}
+
+func TestCatalog_ResolveIssueFields(t *testing.T) {
+	catalog := testCatalog()
+	fields := &IssueFields{
+		Project:  Project{Key: "OPS"},
+		Type:     IssueType{Name: "task"},
+		Priority: &Priority{Name: "High"},
+	}
+	if err := catalog.ResolveIssueFields(fields); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if fields.Project.ID != "10100" || fields.Type.ID != "10001" || fields.Priority.ID != "2" {
+		t.Errorf("Unexpected fields %+v", fields)
+	}
+
+	resolved, err := catalog.ResolveFields(map[string]interface{}{"Story Points": 3, "summary": "x"})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if want := map[string]interface{}{"customfield_10010": 3, "summary": "x"}; !reflect.DeepEqual(resolved, want) {
+		t.Errorf("Expected %v, got %v", want, resolved)
+	}
+	if _, err := catalog.ResolveFields(map[string]interface{}{"Story Points": 3, "customfield_10010": 4}); err == nil {
+		t.Error("Expected an error for a field set twice")
+	}
+}
+
+func TestCatalog_CustomField(t *testing.T) {
+	catalog := testCatalog()
+	issue := &Issue{}
+	if err := catalog.SetCustomField(issue, "Story Points", 5); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if value, ok, err := catalog.CustomField(issue, "customfield_10010"); err != nil || !ok || value != 5 {
+		t.Errorf("Expected 5, got %v, %v, %v", value, ok, err)
+	}
+
+	byName := catalog.CustomFieldsByName(CustomFields{"customfield_10010": "5", "customfield_99999": "x"})
+	if want := (CustomFields{"Story Points": "5", "customfield_99999": "x"}); !reflect.DeepEqual(byName, want) {
+		t.Errorf("Expected %v, got %v", want, byName)
+	}
+}
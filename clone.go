@@ -0,0 +1,467 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// CloneOptions specifies the optional parameters to IssueService.CloneIssue
+type CloneOptions struct {
+	// Project is the key of the project to create the clone in. Defaults to the project of the source issue.
+	Project string
+	// IssueType is the name of the issue type of the clone. Defaults to the issue type of the source issue.
+	// Subtasks keep the name of their issue type.
+	IssueType string
+	// SummaryPrefix is prepended to the summary of the clone, e.g. "CLONE - ".
+	SummaryPrefix string
+
+	Labels       bool
+	Components   bool
+	CustomFields bool
+	Attachments  bool
+	// Links recreates the issue links of the source issue on the clone.
+	Links bool
+	// Subtasks clones the subtasks of the source issue as subtasks of the clone.
+	Subtasks bool
+
+	// FieldMapping maps field IDs of the source issue to field IDs of the target, e.g. when a custom field
+	// has a different ID in the target project. Fields without a mapping keep their ID.
+	FieldMapping map[string]string
+	// ExcludeFields lists the IDs of source fields which are not copied, e.g. "assignee".
+	ExcludeFields []string
+	// LinkType is the name of the issue link type linking the clone to the source issue, e.g. "Cloners".
+	// The clone is linked as inward issue like Jira does. No link is created if empty.
+	LinkType string
+}
+
+// CloneResult describes the issues created by IssueService.CloneIssue.
+type CloneResult struct {
+	// Issue is the clone of the source issue, holding the ID, key and self link returned by Jira.
+	Issue *Issue
+	// Subtasks are the clones of the subtasks of the source issue.
+	Subtasks []*Issue
+	// Keys maps the keys of the source issue and its subtasks to the keys of their clones.
+	Keys map[string]string
+	// Dropped lists the keys and IDs of fields which were not copied, e.g. "PROJ-1:customfield_10010",
+	// because they are not on the create screen of the target or their values are not allowed there.
+	Dropped []string
+	// Attachments are the attachments copied to the clones.
+	Attachments []Attachment
+}
+
+// notCopiedFields are set by CloneIssue itself or cannot be set on create
+var notCopiedFields = map[string]bool{
+	"project":    true,
+	"issuetype":  true,
+	"parent":     true,
+	"attachment": true,
+	"issuelinks": true,
+	"subtasks":   true,
+	"summary":    true,
+}
+
+// CloneIssue creates a copy of the issue, possibly in another project or with another issue type.
+// Summary, description and the other system fields on the create screen of the target are always copied;
+// the options control labels, components, custom fields, attachments, links and subtasks.
+//
+// Values are matched against the create metadata of the target, so fields missing there and option,
+// component or version values which do not exist in the target are dropped and listed in CloneResult.Dropped.
+// The result holds everything created before an error occurred.
+func (s *IssueService) CloneIssue(ctx context.Context, issueID string, options *CloneOptions) (*CloneResult, error) {
+	opts := CloneOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	source, _, err := s.Get(ctx, issueID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &cloner{s: s, opts: opts, targets: newCreateTargets(s)}
+	result := &CloneResult{Keys: make(map[string]string)}
+	return result, c.clone(ctx, source, result)
+}
+
+type cloner struct {
+	s       *IssueService
+	opts    CloneOptions
+	targets *createTargets
+}
+
+func (c *cloner) clone(ctx context.Context, source *Issue, result *CloneResult) error {
+	project := c.opts.Project
+	if project == "" {
+		project = source.Fields.Project.Key
+	}
+	issueType := c.opts.IssueType
+	if issueType == "" {
+		issueType = source.Fields.Type.Name
+	}
+
+	clone, err := c.create(ctx, source, project, issueType, "", result)
+	if clone != nil {
+		result.Issue = clone
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.opts.LinkType != "" {
+		link := &IssueLink{
+			Type:         IssueLinkType{Name: c.opts.LinkType},
+			InwardIssue:  &Issue{Key: clone.Key},
+			OutwardIssue: &Issue{Key: source.Key},
+		}
+		resp, err := c.s.AddLink(ctx, link)
+		if err != nil {
+			return fmt.Errorf("linking %s to %s: %w", clone.Key, source.Key, err)
+		}
+		resp.Body.Close()
+	}
+
+	if c.opts.Subtasks {
+		for _, subtask := range source.Fields.Subtasks {
+			sub, _, err := c.s.Get(ctx, subtask.Key, nil)
+			if err != nil {
+				return err
+			}
+			subClone, err := c.create(ctx, sub, project, sub.Fields.Type.Name, clone.Key, result)
+			if subClone != nil {
+				result.Subtasks = append(result.Subtasks, subClone)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// create clones a single issue and copies its attachments and links
+func (c *cloner) create(ctx context.Context, source *Issue, project, issueType, parentKey string, result *CloneResult) (*Issue, error) {
+	target, err := c.targets.get(ctx, project, issueType)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, dropped, err := c.fields(source, target)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range dropped {
+		result.Dropped = append(result.Dropped, source.Key+":"+id)
+	}
+
+	issue := &Issue{Fields: &IssueFields{
+		Type:     IssueType{ID: target.issueTypeID},
+		Summary:  c.opts.SummaryPrefix + source.Fields.Summary,
+		Unknowns: fields,
+	}}
+	issue.Fields.Unknowns["project"] = target.projectRef()
+	if parentKey != "" {
+		issue.Fields.Parent = &Parent{Key: parentKey}
+	}
+
+	clone, _, err := c.s.Create(ctx, issue)
+	if err != nil {
+		return nil, fmt.Errorf("cloning %s: %w", source.Key, err)
+	}
+	result.Keys[source.Key] = clone.Key
+
+	if c.opts.Attachments {
+		for _, attachment := range source.Fields.Attachments {
+			copied, err := c.copyAttachment(ctx, attachment, clone.Key)
+			if err != nil {
+				return clone, fmt.Errorf("copying attachment %q of %s: %w", attachment.Filename, source.Key, err)
+			}
+			result.Attachments = append(result.Attachments, copied...)
+		}
+	}
+
+	if c.opts.Links {
+		for _, link := range source.Fields.IssueLinks {
+			copied := &IssueLink{Type: IssueLinkType{Name: link.Type.Name}}
+			switch {
+			case link.OutwardIssue != nil:
+				copied.InwardIssue = &Issue{Key: clone.Key}
+				copied.OutwardIssue = &Issue{Key: link.OutwardIssue.Key}
+			case link.InwardIssue != nil:
+				copied.InwardIssue = &Issue{Key: link.InwardIssue.Key}
+				copied.OutwardIssue = &Issue{Key: clone.Key}
+			default:
+				continue
+			}
+			resp, err := c.s.AddLink(ctx, copied)
+			if err != nil {
+				return clone, fmt.Errorf("copying %s link of %s: %w", link.Type.Name, source.Key, err)
+			}
+			resp.Body.Close()
+		}
+	}
+
+	return clone, nil
+}
+
+// fields returns the field values of the source to create the clone with, and the IDs of the dropped fields
+func (c *cloner) fields(source *Issue, target *createTarget) (map[string]interface{}, []string, error) {
+	b, err := json.Marshal(source.Fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, nil, err
+	}
+
+	excluded := make(map[string]bool, len(c.opts.ExcludeFields))
+	for _, id := range c.opts.ExcludeFields {
+		excluded[id] = true
+	}
+
+	fields := make(map[string]interface{})
+	var dropped []string
+	for id, value := range values {
+		if value == nil || notCopiedFields[id] || excluded[id] {
+			continue
+		}
+		custom := strings.HasPrefix(id, "customfield_")
+		if (custom && !c.opts.CustomFields) || (id == "labels" && !c.opts.Labels) || (id == "components" && !c.opts.Components) {
+			continue
+		}
+
+		targetID, mapped := c.opts.FieldMapping[id]
+		if !mapped {
+			targetID = id
+		}
+		field, ok := target.fields[targetID]
+		if !ok {
+			// Read-only system fields like "created" are never on the create screen, so only report fields worth knowing about
+			if custom || mapped || id == "labels" || id == "components" {
+				dropped = append(dropped, id)
+			}
+			continue
+		}
+
+		var copied interface{}
+		switch {
+		case field.Schema.Custom == sprintCustomType:
+			// Sprints are returned in a format which cannot be sent back, and are specific to boards anyway
+			ok = false
+		case field.Schema.System == "timetracking":
+			copied, ok = cloneTimeTracking(value)
+		default:
+			copied, ok = cloneFieldValue(&field, value)
+		}
+		if !ok {
+			dropped = append(dropped, id)
+			continue
+		}
+		fields[targetID] = copied
+	}
+	return fields, dropped, nil
+}
+
+// cloneFieldValue converts a field value of an issue to a value to create an issue with.
+// Objects are reduced to the property identifying them, and matched against the allowed values of the field if it has any.
+func cloneFieldValue(field *ProjectIssueField, value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case nil:
+		return nil, false
+	case []interface{}:
+		items := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			if copied, ok := cloneFieldValue(field, item); ok {
+				items = append(items, copied)
+			}
+		}
+		return items, len(items) > 0 || len(v) == 0
+	case map[string]interface{}:
+		if len(field.AllowedValues) > 0 {
+			return matchAllowedValue(field.AllowedValues, v)
+		}
+		for _, key := range []string{"accountId", "name", "value", "key", "id"} {
+			if id, ok := v[key]; ok && id != "" {
+				return map[string]interface{}{key: id}, true
+			}
+		}
+		return nil, false
+	case string:
+		return v, v != ""
+	}
+	return value, true
+}
+
+// cloneTimeTracking keeps the estimates of a time tracking value, which are the only parts Jira accepts on create
+func cloneTimeTracking(value interface{}) (interface{}, bool) {
+	v, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	estimates := make(map[string]interface{})
+	for _, key := range []string{"originalEstimate", "remainingEstimate"} {
+		if estimate, ok := v[key]; ok {
+			estimates[key] = estimate
+		}
+	}
+	return estimates, len(estimates) > 0
+}
+
+// matchAllowedValue returns a reference to the allowed value with the same value or name as v
+func matchAllowedValue(allowed []AllowedValue, v map[string]interface{}) (interface{}, bool) {
+	value, _ := v["value"].(string)
+	name, _ := v["name"].(string)
+	for _, a := range allowed {
+		if (value != "" && strings.EqualFold(a.Value, value)) || (name != "" && strings.EqualFold(a.Name, name)) {
+			return map[string]interface{}{"id": a.ID}, true
+		}
+	}
+	return nil, false
+}
+
+// copyAttachment streams an attachment from its issue to the issue with the given key
+func (c *cloner) copyAttachment(ctx context.Context, attachment *Attachment, issueKey string) ([]Attachment, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		_, _, err := c.s.DownloadAttachmentTo(ctx, attachment.ID, pw, nil)
+		pw.CloseWithError(err)
+	}()
+
+	file := AttachmentFile{Name: attachment.Filename, Reader: pr, Size: int64(attachment.Size), ContentType: attachment.MimeType}
+	copied, _, err := c.s.UploadAttachments(ctx, issueKey, []AttachmentFile{file}, nil)
+	pr.Close()
+	return copied, err
+}
+
+// MoveOptions specifies the optional parameters to IssueService.Move
+type MoveOptions struct {
+	// Project is the key of the project to move the issue to. Defaults to the project of the issue.
+	Project string
+	// IssueType is the name of the new issue type. Defaults to the issue type of the issue.
+	IssueType string
+	// FieldMapping maps field IDs of the issue to field IDs of the target, see CloneOptions.FieldMapping.
+	FieldMapping map[string]string
+	// StatusMapping maps status names of the issue and its subtasks to status names of the target workflow.
+	// Statuses without a mapping keep their name.
+	StatusMapping map[string]string
+	// DeleteSource deletes the issue and its subtasks once moved. Otherwise the issue is linked to
+	// its replacement with LinkType. The issue is kept if any of its field values could not be copied,
+	// see CloneResult.Dropped.
+	DeleteSource bool
+	// LinkType is the name of the issue link type linking the replacement to the issue, see CloneOptions.LinkType.
+	LinkType string
+}
+
+// Move moves the issue and its subtasks to another project or issue type.
+// The Jira REST API cannot move issues, so the issue is cloned with all fields, labels, components,
+// custom fields, attachments, links and subtasks, and every clone is transitioned to the mapped status
+// of its source. Comments, worklogs and the history are not moved.
+//
+// A status is only reached by a single transition; if none leads there, an error is returned.
+// The result holds everything created before an error occurred.
+func (s *IssueService) Move(ctx context.Context, issueID string, options *MoveOptions) (*CloneResult, error) {
+	opts := MoveOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	source, _, err := s.Get(ctx, issueID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &cloner{s: s, targets: newCreateTargets(s), opts: CloneOptions{
+		Project:      opts.Project,
+		IssueType:    opts.IssueType,
+		Labels:       true,
+		Components:   true,
+		CustomFields: true,
+		Attachments:  true,
+		Links:        true,
+		Subtasks:     true,
+		FieldMapping: opts.FieldMapping,
+	}}
+	if !opts.DeleteSource {
+		c.opts.LinkType = opts.LinkType
+	}
+	result := &CloneResult{Keys: make(map[string]string)}
+	if err := c.clone(ctx, source, result); err != nil {
+		return result, err
+	}
+
+	statuses := map[string]*Status{source.Key: source.Fields.Status}
+	for _, subtask := range source.Fields.Subtasks {
+		statuses[subtask.Key] = subtask.Fields.Status
+	}
+	for sourceKey, status := range statuses {
+		if status == nil {
+			continue
+		}
+		name := status.Name
+		if mapped, ok := opts.StatusMapping[name]; ok {
+			name = mapped
+		}
+		if err := s.transitionToStatus(ctx, result.Keys[sourceKey], name); err != nil {
+			return result, err
+		}
+	}
+
+	if opts.DeleteSource {
+		if len(result.Dropped) > 0 {
+			return result, fmt.Errorf("not deleting %s: fields were not copied: %s", source.Key, strings.Join(result.Dropped, ", "))
+		}
+		resp, err := s.deleteWithSubtasks(ctx, source.Key)
+		if err != nil {
+			return result, fmt.Errorf("deleting %s: %w", source.Key, err)
+		}
+		resp.Body.Close()
+	}
+	return result, nil
+}
+
+// deleteWithSubtasks deletes the issue and its subtasks
+// Caller must close resp.Body
+func (s *IssueService) deleteWithSubtasks(ctx context.Context, issueKey string) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s?deleteSubtasks=true", issueKey)
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, apiEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, NewJiraError(resp, err)
+	}
+	return resp, nil
+}
+
+// transitionToStatus moves the issue to the named status, unless it already has that status
+func (s *IssueService) transitionToStatus(ctx context.Context, issueKey, status string) error {
+	issue, _, err := s.Get(ctx, issueKey, &GetQueryOptions{Fields: "status"})
+	if err != nil {
+		return err
+	}
+	if issue.Fields != nil && issue.Fields.Status != nil && strings.EqualFold(issue.Fields.Status.Name, status) {
+		return nil
+	}
+
+	transitions, _, err := s.GetTransitions(ctx, issueKey)
+	if err != nil {
+		return err
+	}
+	for _, t := range transitions {
+		if strings.EqualFold(t.To.Name, status) {
+			resp, err := s.DoTransition(ctx, issueKey, t.ID)
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: no transition to status %q", issueKey, status)
+}
@@ -0,0 +1,271 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const cloneTestCreateMeta = `{"projects":[{"id":"20000","key":"NEW","name":"New project","issuetypes":[
+	{"id":"3","name":"Task","fields":{
+		"summary":{"required":true,"name":"Summary","schema":{"type":"string","system":"summary"}},
+		"description":{"required":false,"name":"Description","schema":{"type":"string","system":"description"}},
+		"priority":{"required":false,"name":"Priority","schema":{"type":"priority","system":"priority"},
+			"allowedValues":[{"id":"1","name":"High"},{"id":"2","name":"Low"}]},
+		"labels":{"required":false,"name":"Labels","schema":{"type":"array","items":"string","system":"labels"}},
+		"components":{"required":false,"name":"Components","schema":{"type":"array","items":"component","system":"components"},
+			"allowedValues":[{"id":"500","name":"Backend"}]},
+		"customfield_10010":{"required":false,"name":"Team","schema":{"type":"option","custom":"com.atlassian.jira.plugin.system.customfieldtypes:select"},
+			"allowedValues":[{"id":"900","value":"Red"},{"id":"901","value":"Blue"}]},
+		"customfield_10030":{"required":false,"name":"Notes","schema":{"type":"string"}}
+	}},
+	{"id":"5","name":"Sub-task","subtask":true,"fields":{
+		"summary":{"required":true,"name":"Summary","schema":{"type":"string","system":"summary"}}
+	}}]}]}`
+
+func TestIssueService_CloneIssue(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/rest/api/2/issue/OLD-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"id":"10001","key":"OLD-1","fields":{
+			"project":{"id":"10000","key":"OLD"},
+			"issuetype":{"id":"3","name":"Task"},
+			"summary":"Template",
+			"description":"Do the thing",
+			"priority":{"id":"7","name":"High"},
+			"labels":["template"],
+			"components":[{"id":"100","name":"Backend"},{"id":"101","name":"Frontend"}],
+			"customfield_10010":{"id":"800","value":"Blue"},
+			"customfield_10020":"not on the target screen",
+			"customfield_10040":"renamed",
+			"attachment":[{"id":"300","filename":"spec.txt","size":5,"mimeType":"text/plain"}],
+			"issuelinks":[{"id":"1","type":{"name":"Blocks"},"outwardIssue":{"key":"OLD-9"}}],
+			"subtasks":[{"id":"10002","key":"OLD-2","fields":{"summary":"Step"}}]}}`)
+	})
+	testMux.HandleFunc("/rest/api/2/issue/OLD-2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"id":"10002","key":"OLD-2","fields":{"project":{"key":"OLD"},"issuetype":{"name":"Sub-task"},"summary":"Step"}}`)
+	})
+	testMux.HandleFunc("/rest/api/2/issue/createmeta", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if got := r.URL.Query().Get("projectKeys"); got != "NEW" {
+			t.Errorf("Expected create metadata of NEW, got %q", got)
+		}
+		fmt.Fprint(w, cloneTestCreateMeta)
+	})
+
+	var created []map[string]interface{}
+	testMux.HandleFunc("/rest/api/2/issue", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		var body struct {
+			Fields map[string]interface{} `json:"fields"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		created = append(created, body.Fields)
+		fmt.Fprintf(w, `{"id":"2000%d","key":"NEW-%d"}`, len(created), len(created))
+	})
+
+	var links []string
+	testMux.HandleFunc("/rest/api/2/issueLink", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		var link IssueLink
+		if err := json.NewDecoder(r.Body).Decode(&link); err != nil {
+			t.Fatal(err)
+		}
+		links = append(links, fmt.Sprintf("%s %s->%s", link.Type.Name, link.InwardIssue.Key, link.OutwardIssue.Key))
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	testMux.HandleFunc("/secure/attachment/300/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, "hello")
+	})
+	testMux.HandleFunc("/rest/api/2/issue/NEW-1/attachments", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := io.ReadAll(file)
+		if header.Filename != "spec.txt" || string(content) != "hello" {
+			t.Errorf("Unexpected attachment %q with content %q", header.Filename, content)
+		}
+		fmt.Fprint(w, `[{"id":"400","filename":"spec.txt"}]`)
+	})
+
+	result, err := testClient.Issue.CloneIssue(context.Background(), "OLD-1", &CloneOptions{
+		Project:       "NEW",
+		SummaryPrefix: "CLONE - ",
+		Labels:        true,
+		Components:    true,
+		CustomFields:  true,
+		Attachments:   true,
+		Links:         true,
+		Subtasks:      true,
+		FieldMapping:  map[string]string{"customfield_10040": "customfield_10030"},
+		LinkType:      "Cloners",
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	if len(created) != 2 {
+		t.Fatalf("Expected 2 created issues, got %d", len(created))
+	}
+	wantFields := map[string]interface{}{
+		"project":           map[string]interface{}{"id": "20000"},
+		"issuetype":         map[string]interface{}{"id": "3"},
+		"summary":           "CLONE - Template",
+		"description":       "Do the thing",
+		"priority":          map[string]interface{}{"id": "1"},
+		"labels":            []interface{}{"template"},
+		"components":        []interface{}{map[string]interface{}{"id": "500"}},
+		"customfield_10010": map[string]interface{}{"id": "901"},
+		"customfield_10030": "renamed",
+	}
+	if !reflect.DeepEqual(created[0], wantFields) {
+		t.Errorf("Unexpected fields of the clone:\n got %v\nwant %v", created[0], wantFields)
+	}
+	if parent, _ := created[1]["parent"].(map[string]interface{}); parent["key"] != "NEW-1" || created[1]["summary"] != "CLONE - Step" {
+		t.Errorf("Unexpected fields of the subtask clone: %v", created[1])
+	}
+
+	sort.Strings(links)
+	if want := []string{"Blocks NEW-1->OLD-9", "Cloners NEW-1->OLD-1"}; !reflect.DeepEqual(links, want) {
+		t.Errorf("Expected links %v, got %v", want, links)
+	}
+	if result.Issue.Key != "NEW-1" || len(result.Subtasks) != 1 || result.Subtasks[0].Key != "NEW-2" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if result.Keys["OLD-2"] != "NEW-2" || len(result.Attachments) != 1 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if want := []string{"OLD-1:customfield_10020"}; !reflect.DeepEqual(result.Dropped, want) {
+		t.Errorf("Expected dropped fields %v, got %v", want, result.Dropped)
+	}
+}
+
+func TestIssueService_Move(t *testing.T) {
+	setup()
+	defer teardown()
+
+	deleted := false
+	testMux.HandleFunc("/rest/api/2/issue/OLD-1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"id":"10001","key":"OLD-1","fields":{"project":{"key":"OLD"},"issuetype":{"name":"Story"},
+				"summary":"Move me","status":{"name":"In Progress"}}}`)
+		case http.MethodDelete:
+			testRequestURL(t, r, "/rest/api/2/issue/OLD-1?deleteSubtasks=true")
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	testMux.HandleFunc("/rest/api/2/issue/createmeta", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, cloneTestCreateMeta)
+	})
+	testMux.HandleFunc("/rest/api/2/issue", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		fmt.Fprint(w, `{"id":"20001","key":"NEW-1"}`)
+	})
+	testMux.HandleFunc("/rest/api/2/issue/NEW-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"key":"NEW-1","fields":{"status":{"name":"To Do"}}}`)
+	})
+	var transitioned string
+	testMux.HandleFunc("/rest/api/2/issue/NEW-1/transitions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"transitions":[{"id":"11","to":{"name":"Done"}},{"id":"21","to":{"name":"Doing"}}]}`)
+		case http.MethodPost:
+			b, _ := io.ReadAll(r.Body)
+			transitioned = string(b)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	result, err := testClient.Issue.Move(context.Background(), "OLD-1", &MoveOptions{
+		Project:       "NEW",
+		IssueType:     "Task",
+		StatusMapping: map[string]string{"In Progress": "Doing"},
+		DeleteSource:  true,
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if result.Keys["OLD-1"] != "NEW-1" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if !strings.Contains(transitioned, `"id":"21"`) {
+		t.Errorf("Expected transition 21, got %s", transitioned)
+	}
+	if !deleted {
+		t.Error("Expected OLD-1 to be deleted")
+	}
+}
+
+func TestIssueService_Move_KeepsSourceWithDroppedFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/rest/api/2/issue/OLD-1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			t.Error("Expected OLD-1 not to be deleted")
+		}
+		fmt.Fprint(w, `{"key":"OLD-1","fields":{"project":{"key":"OLD"},"issuetype":{"name":"Story"},"summary":"Move me",
+			"customfield_10099":"lost"}}`)
+	})
+	testMux.HandleFunc("/rest/api/2/issue/createmeta", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, cloneTestCreateMeta)
+	})
+	testMux.HandleFunc("/rest/api/2/issue", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"key":"NEW-1"}`)
+	})
+
+	result, err := testClient.Issue.Move(context.Background(), "OLD-1", &MoveOptions{Project: "NEW", IssueType: "Task", DeleteSource: true})
+	if err == nil || !strings.Contains(err.Error(), "OLD-1:customfield_10099") {
+		t.Errorf("Expected an error listing the dropped field, got %v", err)
+	}
+	if result == nil || !reflect.DeepEqual(result.Dropped, []string{"OLD-1:customfield_10099"}) {
+		t.Errorf("Unexpected result %+v", result)
+	}
+}
+
+func TestIssueService_Move_NoTransition(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/rest/api/2/issue/OLD-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"key":"OLD-1","fields":{"project":{"key":"NEW"},"issuetype":{"name":"Task"},"summary":"Move me","status":{"name":"Blocked"}}}`)
+	})
+	testMux.HandleFunc("/rest/api/2/issue/createmeta", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, cloneTestCreateMeta)
+	})
+	testMux.HandleFunc("/rest/api/2/issue", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"key":"NEW-1"}`)
+	})
+	testMux.HandleFunc("/rest/api/2/issue/NEW-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"key":"NEW-1","fields":{"status":{"name":"To Do"}}}`)
+	})
+	testMux.HandleFunc("/rest/api/2/issue/NEW-1/transitions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"transitions":[{"id":"11","to":{"name":"Done"}}]}`)
+	})
+
+	result, err := testClient.Issue.Move(context.Background(), "OLD-1", nil)
+	if err == nil {
+		t.Fatal("Expected an error for an unreachable status")
+	}
+	if result == nil || result.Issue == nil || result.Issue.Key != "NEW-1" {
+		t.Errorf("Expected the created issue in the result, got %+v", result)
+	}
+}
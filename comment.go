@@ -0,0 +1,165 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Comment visibility types
+const (
+	CommentVisibilityGroup = "group"
+	CommentVisibilityRole  = "role"
+)
+
+// GroupVisibility restricts a comment to the members of the group.
+func GroupVisibility(group string) CommentVisibility {
+	return CommentVisibility{Type: CommentVisibilityGroup, Value: group}
+}
+
+// RoleVisibility restricts a comment to the users in the project role, e.g. "Administrators".
+func RoleVisibility(role string) CommentVisibility {
+	return CommentVisibility{Type: CommentVisibilityRole, Value: role}
+}
+
+// IsRestricted reports whether the comment is only visible to a group or a project role.
+func (c *Comment) IsRestricted() bool {
+	return c.Visibility.Type != "" && c.Visibility.Value != ""
+}
+
+// GetCommentsOptions specifies the optional parameters to IssueService.GetComments
+type GetCommentsOptions struct {
+	// StartAt: The index of the first comment to return. Base index: 0.
+	StartAt int `url:"startAt,omitempty"`
+	// MaxResults: The maximum number of comments to return per page. Default: 50.
+	MaxResults int `url:"maxResults,omitempty"`
+	// OrderBy: The field to sort comments by, "created" or "-created" for descending order.
+	OrderBy string `url:"orderBy,omitempty"`
+	// Expand: Use "renderedBody" to return the comment bodies rendered in HTML.
+	Expand string `url:"expand,omitempty"`
+}
+
+// GetComments returns a page of comments of an issue.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/issue-getComments
+func (s *IssueService) GetComments(ctx context.Context, issueID string, options *GetCommentsOptions) (*Comments, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/comment", issueID)
+	url, err := addOptions(apiEndpoint, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comments := new(Comments)
+	resp, err := s.client.Do(req, comments)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return comments, resp, nil
+}
+
+// GetCommentOptions specifies the optional parameters to IssueService.GetComment
+type GetCommentOptions struct {
+	// Expand: Use "renderedBody" to return the comment body rendered in HTML.
+	Expand string `url:"expand,omitempty"`
+}
+
+// GetComment returns a single comment of an issue.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/issue-getComment
+func (s *IssueService) GetComment(ctx context.Context, issueID, commentID string, options *GetCommentOptions) (*Comment, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/comment/%s", issueID, commentID)
+	url, err := addOptions(apiEndpoint, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comment := new(Comment)
+	resp, err := s.client.Do(req, comment)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return comment, resp, nil
+}
+
+// CommentPages calls f for every comment of an issue, fetching one page of comments after the other.
+// Iteration stops at the first error returned by f.
+func (s *IssueService) CommentPages(ctx context.Context, issueID string, options *GetCommentsOptions, f func(*Comment) error) error {
+	opts := GetCommentsOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.MaxResults == 0 {
+		opts.MaxResults = 50
+	}
+
+	for {
+		comments, _, err := s.GetComments(ctx, issueID, &opts)
+		if err != nil {
+			return err
+		}
+		for _, comment := range comments.Comments {
+			if err := f(comment); err != nil {
+				return err
+			}
+		}
+
+		opts.StartAt += len(comments.Comments)
+		if len(comments.Comments) == 0 || opts.StartAt >= comments.Total {
+			return nil
+		}
+	}
+}
+
+// SearchCommentPages calls f for every comment of every issue matching jql.
+// The issues are searched with SearchPages and only carry their ID and key.
+// Iteration stops at the first error returned by f.
+func (s *IssueService) SearchCommentPages(ctx context.Context, jql string, options *GetCommentsOptions, f func(Issue, *Comment) error) error {
+	return s.SearchPages(ctx, jql, &SearchOptions{MaxResults: 50, Fields: []string{"key"}}, func(issue Issue) error {
+		return s.CommentPages(ctx, issue.Key, options, func(comment *Comment) error {
+			return f(issue, comment)
+		})
+	})
+}
+
+// GetCommentPropertiesKeys returns the keys of all properties of a comment.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/comment/{commentId}/properties-getPropertiesKeys
+func (s *IssueService) GetCommentPropertiesKeys(ctx context.Context, commentID string) (*PropertyKeys, *Response, error) {
+	return s.client.Property.GetKeys(ctx, CommentEntity(commentID))
+}
+
+// GetCommentProperty returns the value of the property with the given key from a comment.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/comment/{commentId}/properties-getProperty
+func (s *IssueService) GetCommentProperty(ctx context.Context, commentID, propertyKey string) (*EntityProperty, *Response, error) {
+	return s.client.Property.Get(ctx, CommentEntity(commentID), propertyKey)
+}
+
+// SetCommentProperty sets the value of the property with the given key on a comment.
+// The value must be serializable to JSON.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/comment/{commentId}/properties-setProperty
+// Caller must close resp.Body
+func (s *IssueService) SetCommentProperty(ctx context.Context, commentID, propertyKey string, value interface{}) (*Response, error) {
+	return s.client.Property.Set(ctx, CommentEntity(commentID), propertyKey, value)
+}
+
+// DeleteCommentProperty removes the property with the given key from a comment.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/comment/{commentId}/properties-deleteProperty
+// Caller must close resp.Body
+func (s *IssueService) DeleteCommentProperty(ctx context.Context, commentID, propertyKey string) (*Response, error) {
+	return s.client.Property.Delete(ctx, CommentEntity(commentID), propertyKey)
+}
@@ -0,0 +1,126 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestIssueService_GetComments(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/10000/comment", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testRequestURL(t, r, "/rest/api/2/issue/10000/comment?expand=renderedBody&maxResults=2&orderBy=-created&startAt=1")
+
+		fmt.Fprint(w, `{"startAt":1,"maxResults":2,"total":5,"comments":[
+			{"id":"10002","body":"*bold*","renderedBody":"<b>bold</b>","visibility":{"type":"role","value":"Administrators"}},
+			{"id":"10001","body":"plain"}]}`)
+	})
+
+	comments, _, err := testClient.Issue.GetComments(context.Background(), "10000", &GetCommentsOptions{StartAt: 1, MaxResults: 2, OrderBy: "-created", Expand: "renderedBody"})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if comments.Total != 5 || len(comments.Comments) != 2 {
+		t.Fatalf("Expected 2 of 5 comments, got %+v", comments)
+	}
+	if c := comments.Comments[0]; c.RenderedBody != "<b>bold</b>" || !c.IsRestricted() || c.Visibility != RoleVisibility("Administrators") {
+		t.Errorf("Unexpected comment %+v", c)
+	}
+	if comments.Comments[1].IsRestricted() {
+		t.Error("Expected the second comment to be visible to everyone")
+	}
+}
+
+func TestIssueService_GetComment(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/10000/comment/10001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testRequestURL(t, r, "/rest/api/2/issue/10000/comment/10001?expand=renderedBody")
+
+		fmt.Fprint(w, `{"id":"10001","body":"plain","renderedBody":"<p>plain</p>"}`)
+	})
+
+	comment, _, err := testClient.Issue.GetComment(context.Background(), "10000", "10001", &GetCommentOptions{Expand: "renderedBody"})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if comment.ID != "10001" || comment.RenderedBody != "<p>plain</p>" {
+		t.Errorf("Unexpected comment %+v", comment)
+	}
+}
+
+func TestIssueService_SearchCommentPages(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"startAt":0,"maxResults":50,"total":2,"issues":[{"key":"TEST-1"},{"key":"TEST-2"}]}`)
+	})
+	testMux.HandleFunc("/rest/api/2/issue/TEST-1/comment", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("startAt") == "" {
+			fmt.Fprint(w, `{"startAt":0,"maxResults":2,"total":3,"comments":[{"id":"1"},{"id":"2"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"startAt":2,"maxResults":2,"total":3,"comments":[{"id":"3"}]}`)
+	})
+	testMux.HandleFunc("/rest/api/2/issue/TEST-2/comment", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"startAt":0,"maxResults":2,"total":0,"comments":[]}`)
+	})
+
+	var got []string
+	err := testClient.Issue.SearchCommentPages(context.Background(), "project = TEST", &GetCommentsOptions{MaxResults: 2}, func(issue Issue, comment *Comment) error {
+		got = append(got, issue.Key+"/"+comment.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if fmt.Sprint(got) != "[TEST-1/1 TEST-1/2 TEST-1/3]" {
+		t.Errorf("Unexpected comments %v", got)
+	}
+}
+
+func TestIssueService_CommentProperties(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/comment/10001/properties", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"keys":[{"self":"https://jira/rest/api/2/comment/10001/properties/review","key":"review"}]}`)
+	})
+	testMux.HandleFunc("/rest/api/2/comment/10001/properties/review", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"key":"review","value":{"approved":true}}`)
+		case http.MethodPut:
+			var value map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&value); err != nil || value["approved"] != true {
+				t.Errorf("Unexpected property value %v (%v)", value, err)
+			}
+			w.WriteHeader(http.StatusOK)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+
+	keys, _, err := testClient.Issue.GetCommentPropertiesKeys(context.Background(), "10001")
+	if err != nil || len(keys.Keys) != 1 || keys.Keys[0].Key != "review" {
+		t.Errorf("Unexpected keys %+v (%v)", keys, err)
+	}
+	property, _, err := testClient.Issue.GetCommentProperty(context.Background(), "10001", "review")
+	if err != nil || property.Key != "review" {
+		t.Errorf("Unexpected property %+v (%v)", property, err)
+	}
+	if _, err := testClient.Issue.SetCommentProperty(context.Background(), "10001", "review", map[string]bool{"approved": true}); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if _, err := testClient.Issue.DeleteCommentProperty(context.Background(), "10001", "review"); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
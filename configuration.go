@@ -0,0 +1,115 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+)
+
+// ConfigurationService handles the global settings of the Jira instance / API.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/configuration
+type ConfigurationService service
+
+// Configuration represents the global settings of a Jira instance.
+type Configuration struct {
+	VotingEnabled             bool                       `json:"votingEnabled" structs:"votingEnabled"`
+	WatchingEnabled           bool                       `json:"watchingEnabled" structs:"watchingEnabled"`
+	UnassignedIssuesAllowed   bool                       `json:"unassignedIssuesAllowed" structs:"unassignedIssuesAllowed"`
+	SubTasksEnabled           bool                       `json:"subTasksEnabled" structs:"subTasksEnabled"`
+	IssueLinkingEnabled       bool                       `json:"issueLinkingEnabled" structs:"issueLinkingEnabled"`
+	TimeTrackingEnabled       bool                       `json:"timeTrackingEnabled" structs:"timeTrackingEnabled"`
+	AttachmentsEnabled        bool                       `json:"attachmentsEnabled" structs:"attachmentsEnabled"`
+	TimeTrackingConfiguration *TimeTrackingConfiguration `json:"timeTrackingConfiguration,omitempty" structs:"timeTrackingConfiguration,omitempty"`
+}
+
+// Get returns the global settings of the Jira instance.
+// TimeTrackingConfiguration is only set if time tracking is enabled.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/configuration-getConfiguration
+func (s *ConfigurationService) Get(ctx context.Context) (*Configuration, *Response, error) {
+	apiEndpoint := "rest/api/2/configuration"
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	configuration := new(Configuration)
+	resp, err := s.client.Do(req, configuration)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return configuration, resp, nil
+}
+
+// GetTimeTrackingConfiguration returns the time tracking settings of the Jira instance.
+// If time tracking is disabled, DefaultTimeTrackingConfiguration is returned.
+func (s *ConfigurationService) GetTimeTrackingConfiguration(ctx context.Context) (*TimeTrackingConfiguration, *Response, error) {
+	configuration, resp, err := s.Get(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+	if configuration.TimeTrackingConfiguration == nil {
+		return DefaultTimeTrackingConfiguration(), resp, nil
+	}
+	return configuration.TimeTrackingConfiguration, resp, nil
+}
+
+// Deployment types of Jira instances
+const (
+	DeploymentTypeCloud      = "Cloud"
+	DeploymentTypeServer     = "Server"
+	DeploymentTypeDataCenter = "DataCenter"
+)
+
+// ServerInfo represents general information about the Jira instance.
+type ServerInfo struct {
+	BaseURL        string `json:"baseUrl" structs:"baseUrl"`
+	Version        string `json:"version" structs:"version"`
+	VersionNumbers []int  `json:"versionNumbers" structs:"versionNumbers"`
+	// DeploymentType is one of DeploymentTypeCloud, DeploymentTypeServer or DeploymentTypeDataCenter.
+	// Old Jira Server versions do not report it.
+	DeploymentType string `json:"deploymentType,omitempty" structs:"deploymentType,omitempty"`
+	BuildNumber    int    `json:"buildNumber" structs:"buildNumber"`
+	BuildDate      string `json:"buildDate,omitempty" structs:"buildDate,omitempty"`
+	ServerTime     string `json:"serverTime,omitempty" structs:"serverTime,omitempty"`
+	ScmInfo        string `json:"scmInfo,omitempty" structs:"scmInfo,omitempty"`
+	ServerTitle    string `json:"serverTitle" structs:"serverTitle"`
+}
+
+// GetServerInfo returns general information about the Jira instance.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/serverInfo-getServerInfo
+func (s *ConfigurationService) GetServerInfo(ctx context.Context) (*ServerInfo, *Response, error) {
+	apiEndpoint := "rest/api/2/serverInfo"
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info := new(ServerInfo)
+	resp, err := s.client.Do(req, info)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return info, resp, nil
+}
+
+// IsCloud reports whether the instance is Jira Cloud, which identifies users by account ID instead of name.
+// The deployment type is fetched once and cached by the client.
+func (s *ConfigurationService) IsCloud(ctx context.Context) (bool, error) {
+	c := s.client
+	c.deploymentMu.Lock()
+	defer c.deploymentMu.Unlock()
+
+	if c.deploymentType == "" {
+		info, _, err := s.GetServerInfo(ctx)
+		if err != nil {
+			return false, err
+		}
+		c.deploymentType = info.DeploymentType
+		if c.deploymentType == "" {
+			c.deploymentType = DeploymentTypeServer
+		}
+	}
+	return c.deploymentType == DeploymentTypeCloud, nil
+}
@@ -0,0 +1,31 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestConfigurationService_IsCloud(t *testing.T) {
+	setup()
+	defer teardown()
+	calls := 0
+	testMux.HandleFunc("/rest/api/2/serverInfo", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"version":"1001.0.0","deploymentType":"Cloud"}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		cloud, err := testClient.Configuration.IsCloud(context.Background())
+		if err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+		if !cloud {
+			t.Error("Expected a Cloud instance")
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected the deployment type to be cached, got %d requests", calls)
+	}
+}
@@ -0,0 +1,173 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/trivago/tgo/tcontainer"
+)
+
+// DefaultCreateMetaCacheTTL is how long create metadata is cached by new clients, see IssueService.SetCreateMetaCacheTTL.
+const DefaultCreateMetaCacheTTL = 5 * time.Minute
+
+type createMetaEntry struct {
+	project   *MetaProject
+	issueType *MetaIssueType
+	expires   time.Time
+}
+
+// SetCreateMetaCacheTTL sets how long GetCreateMetaProject and GetCreateMetaIssueType cache create metadata.
+// A ttl of 0 disables caching. Changing the ttl clears the cache.
+func (s *IssueService) SetCreateMetaCacheTTL(ttl time.Duration) {
+	c := s.client
+	c.createMetaMu.Lock()
+	defer c.createMetaMu.Unlock()
+	c.createMetaTTL = ttl
+	c.createMetaCache = nil
+}
+
+// InvalidateCreateMeta removes the cached create metadata of the project, or of all projects if projectKey is empty.
+// Call it after changing the screens or fields of a project.
+func (s *IssueService) InvalidateCreateMeta(projectKey string) {
+	c := s.client
+	c.createMetaMu.Lock()
+	defer c.createMetaMu.Unlock()
+	if projectKey == "" {
+		c.createMetaCache = nil
+		return
+	}
+	prefix := strings.ToUpper(projectKey) + "/"
+	for key := range c.createMetaCache {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.createMetaCache, key)
+		}
+	}
+}
+
+func (s *IssueService) cachedCreateMeta(key string) (createMetaEntry, bool) {
+	c := s.client
+	c.createMetaMu.Lock()
+	defer c.createMetaMu.Unlock()
+	entry, ok := c.createMetaCache[key]
+	if !ok || time.Now().After(entry.expires) {
+		return createMetaEntry{}, false
+	}
+	return entry, true
+}
+
+func (s *IssueService) cacheCreateMeta(key string, entry createMetaEntry) {
+	c := s.client
+	c.createMetaMu.Lock()
+	defer c.createMetaMu.Unlock()
+	if c.createMetaTTL <= 0 {
+		return
+	}
+	if c.createMetaCache == nil {
+		c.createMetaCache = make(map[string]createMetaEntry)
+	}
+	entry.expires = time.Now().Add(c.createMetaTTL)
+	c.createMetaCache[key] = entry
+}
+
+// GetCreateMetaProject returns the issue types which can be created in the project, without their fields.
+// It uses the paginated endpoints of Jira 8.4 and later, and falls back to GetCreateMeta on instances
+// which do not have them. Projects returned by the paginated endpoints only have a Key.
+// The result is cached and must not be modified.
+func (s *IssueService) GetCreateMetaProject(ctx context.Context, projectKey string) (*MetaProject, error) {
+	cacheKey := strings.ToUpper(projectKey) + "/"
+	if entry, ok := s.cachedCreateMeta(cacheKey); ok {
+		return entry.project, nil
+	}
+
+	project := &MetaProject{Key: projectKey}
+	types, resp, err := s.GetProjectIssueTypes(ctx, projectKey)
+	switch {
+	case err == nil:
+		for i := range types {
+			project.IssueTypes = append(project.IssueTypes, &types[i])
+		}
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		meta, _, err := s.GetCreateMeta(ctx, &GetQueryOptions{ProjectKeys: projectKey})
+		if err != nil {
+			return nil, err
+		}
+		project = meta.GetProjectWithKey(projectKey)
+		if project == nil {
+			return nil, fmt.Errorf("project %s not found in create metadata", projectKey)
+		}
+	default:
+		return nil, NewJiraError(resp, err)
+	}
+
+	s.cacheCreateMeta(cacheKey, createMetaEntry{project: project})
+	return project, nil
+}
+
+// GetCreateMetaIssueType returns the issue type with the given name, case insensitive, including its fields
+// in the same form as GetCreateMeta with the "projects.issuetypes.fields" expansion.
+// Like GetCreateMetaProject, it works with the paginated endpoints of Jira 8.4 and later as well as GetCreateMeta.
+// The result is cached and must not be modified.
+func (s *IssueService) GetCreateMetaIssueType(ctx context.Context, projectKey, issueTypeName string) (*MetaProject, *MetaIssueType, error) {
+	project, err := s.GetCreateMetaProject(ctx, projectKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	issueType := project.GetIssueTypeWithName(issueTypeName)
+	if issueType == nil {
+		return nil, nil, fmt.Errorf("issue type %q not found in project %s", issueTypeName, projectKey)
+	}
+
+	cacheKey := strings.ToUpper(projectKey) + "/" + issueType.Id
+	if entry, ok := s.cachedCreateMeta(cacheKey); ok {
+		return project, entry.issueType, nil
+	}
+
+	withFields := *issueType
+	fields, resp, err := s.GetProjectIssueFields(ctx, projectKey, issueType.Id)
+	switch {
+	case err == nil:
+		withFields.Fields, err = projectIssueFieldsMap(fields)
+		if err != nil {
+			return nil, nil, err
+		}
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		meta, _, err := s.GetCreateMeta(ctx, &GetQueryOptions{ProjectKeys: projectKey, IssueTypeIds: issueType.Id, Expand: "projects.issuetypes.fields"})
+		if err != nil {
+			return nil, nil, err
+		}
+		metaProject := meta.GetProjectWithKey(projectKey)
+		if metaProject == nil || metaProject.GetIssueTypeWithName(issueTypeName) == nil {
+			return nil, nil, fmt.Errorf("issue type %q not found in project %s", issueTypeName, projectKey)
+		}
+		withFields = *metaProject.GetIssueTypeWithName(issueTypeName)
+	default:
+		return nil, nil, NewJiraError(resp, err)
+	}
+
+	s.cacheCreateMeta(cacheKey, createMetaEntry{issueType: &withFields})
+	return project, &withFields, nil
+}
+
+// projectIssueFieldsMap converts fields of GetProjectIssueFields to the fields of a MetaIssueType, keyed by field ID
+func projectIssueFieldsMap(fields []ProjectIssueField) (tcontainer.MarshalMap, error) {
+	m := tcontainer.NewMarshalMap()
+	for _, f := range fields {
+		b, err := json.Marshal(f)
+		if err != nil {
+			return nil, err
+		}
+		var raw map[string]interface{}
+		if err := json.Unmarshal(b, &raw); err != nil {
+			return nil, err
+		}
+		// Always include the properties the MetaIssueType helpers rely on, even if they are empty
+		raw["required"] = f.Required
+		raw["name"] = f.Name
+		m[f.FieldID] = raw
+	}
+	return m, nil
+}
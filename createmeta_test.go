@@ -0,0 +1,138 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func testCreateMetaPages(t *testing.T) (issueTypeRequests, fieldRequests *int) {
+	issueTypeRequests, fieldRequests = new(int), new(int)
+	testMux.HandleFunc("/rest/api/2/issue/createmeta/OPS/issuetypes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		*issueTypeRequests++
+		switch r.URL.Query().Get("startAt") {
+		case "":
+			fmt.Fprint(w, `{"maxResults":1,"startAt":0,"total":2,"isLast":false,"values":[{"id":"1","name":"Bug"}]}`)
+		case "1":
+			fmt.Fprint(w, `{"maxResults":1,"startAt":1,"total":2,"isLast":true,"values":[{"id":"3","name":"Task"}]}`)
+		default:
+			t.Errorf("Unexpected page %s", r.URL.RawQuery)
+		}
+	})
+	testMux.HandleFunc("/rest/api/2/issue/createmeta/OPS/issuetypes/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		*fieldRequests++
+		switch r.URL.Query().Get("startAt") {
+		case "":
+			fmt.Fprint(w, `{"maxResults":1,"startAt":0,"total":2,"isLast":false,"values":[
+				{"required":true,"schema":{"type":"string","system":"summary"},"name":"Summary","fieldId":"summary"}]}`)
+		case "1":
+			fmt.Fprint(w, `{"maxResults":1,"startAt":1,"total":2,"isLast":true,"values":[
+				{"schema":{"type":"number"},"name":"Story Points","fieldId":"customfield_10001"}]}`)
+		default:
+			t.Errorf("Unexpected page %s", r.URL.RawQuery)
+		}
+	})
+	return issueTypeRequests, fieldRequests
+}
+
+func TestIssueService_GetProjectIssueTypes_Pages(t *testing.T) {
+	setup()
+	defer teardown()
+	testCreateMetaPages(t)
+
+	types, _, err := testClient.Issue.GetProjectIssueTypes(context.Background(), "OPS")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(types) != 2 || types[1].Name != "Task" {
+		t.Errorf("Expected both pages of issue types, got %+v", types)
+	}
+
+	fields, _, err := testClient.Issue.GetProjectIssueFields(context.Background(), "OPS", "3")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(fields) != 2 || fields[1].FieldID != "customfield_10001" {
+		t.Errorf("Expected both pages of fields, got %+v", fields)
+	}
+}
+
+func TestIssueService_GetCreateMetaIssueType(t *testing.T) {
+	setup()
+	defer teardown()
+	issueTypeRequests, fieldRequests := testCreateMetaPages(t)
+
+	for i := 0; i < 2; i++ {
+		project, issueType, err := testClient.Issue.GetCreateMetaIssueType(context.Background(), "OPS", "task")
+		if err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+		if project.Key != "OPS" || issueType.Id != "3" {
+			t.Errorf("Unexpected project %+v and issue type %+v", project, issueType)
+		}
+		mandatory, err := issueType.GetMandatoryFields()
+		if err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+		if len(mandatory) != 1 || mandatory["Summary"] != "summary" {
+			t.Errorf("Unexpected mandatory fields %v", mandatory)
+		}
+	}
+	if *issueTypeRequests != 2 || *fieldRequests != 2 {
+		t.Errorf("Expected the metadata to be fetched once, got %d issue type and %d field requests", *issueTypeRequests, *fieldRequests)
+	}
+
+	testClient.Issue.InvalidateCreateMeta("OPS")
+	if _, _, err := testClient.Issue.GetCreateMetaIssueType(context.Background(), "OPS", "Task"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if *issueTypeRequests != 4 || *fieldRequests != 4 {
+		t.Errorf("Expected the metadata to be fetched again, got %d issue type and %d field requests", *issueTypeRequests, *fieldRequests)
+	}
+
+	if _, _, err := testClient.Issue.GetCreateMetaIssueType(context.Background(), "OPS", "Epic"); err == nil {
+		t.Error("Expected an error for an unknown issue type")
+	}
+}
+
+func TestIssueService_GetCreateMetaIssueType_NoCache(t *testing.T) {
+	setup()
+	defer teardown()
+	issueTypeRequests, _ := testCreateMetaPages(t)
+
+	testClient.Issue.SetCreateMetaCacheTTL(0)
+	for i := 0; i < 2; i++ {
+		if _, err := testClient.Issue.GetCreateMetaProject(context.Background(), "OPS"); err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+	}
+	if *issueTypeRequests != 4 {
+		t.Errorf("Expected the metadata to be fetched every time, got %d requests", *issueTypeRequests)
+	}
+}
+
+func TestIssueService_GetCreateMetaIssueType_Legacy(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/createmeta", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if r.URL.Query().Get("expand") == "" {
+			fmt.Fprint(w, `{"projects":[{"id":"10000","key":"OPS","issuetypes":[{"id":"3","name":"Task"}]}]}`)
+			return
+		}
+		testRequestURL(t, r, "/rest/api/2/issue/createmeta?expand=projects.issuetypes.fields&issuetypeIds=3&projectKeys=OPS")
+		fmt.Fprint(w, `{"projects":[{"id":"10000","key":"OPS","issuetypes":[{"id":"3","name":"Task","fields":{
+			"summary":{"required":true,"name":"Summary","schema":{"type":"string"}}}}]}]}`)
+	})
+
+	project, issueType, err := testClient.Issue.GetCreateMetaIssueType(context.Background(), "OPS", "Task")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if project.Id != "10000" || len(issueType.Fields) != 1 {
+		t.Errorf("Unexpected project %+v and issue type %+v", project, issueType)
+	}
+}
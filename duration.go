@@ -0,0 +1,279 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// TimeTrackingConfiguration represents the time tracking settings of a Jira instance.
+// They define how Jira durations with weeks and days like "1w 2d" convert to time.
+type TimeTrackingConfiguration struct {
+	WorkingHoursPerDay float64 `json:"workingHoursPerDay" structs:"workingHoursPerDay"`
+	WorkingDaysPerWeek float64 `json:"workingDaysPerWeek" structs:"workingDaysPerWeek"`
+	// TimeFormat is "pretty", "days" or "hours".
+	TimeFormat string `json:"timeFormat,omitempty" structs:"timeFormat,omitempty"`
+	// DefaultUnit is the unit of durations without a unit, e.g. "minute".
+	DefaultUnit string `json:"defaultUnit,omitempty" structs:"defaultUnit,omitempty"`
+}
+
+// defaultTimeTrackingConfiguration holds the time tracking settings of a new Jira instance
+var defaultTimeTrackingConfiguration = TimeTrackingConfiguration{
+	WorkingHoursPerDay: 8,
+	WorkingDaysPerWeek: 5,
+	TimeFormat:         "pretty",
+	DefaultUnit:        "minute",
+}
+
+// DefaultTimeTrackingConfiguration returns the time tracking settings of a new Jira instance,
+// 8 hours per day and 5 days per week. They are used by ParseDuration.
+func DefaultTimeTrackingConfiguration() *TimeTrackingConfiguration {
+	c := defaultTimeTrackingConfiguration
+	return &c
+}
+
+// Duration is a length of time tracked in Jira, e.g. a time estimate or the time spent of a worklog.
+// Jira durations have a precision of seconds.
+//
+// In JSON, a Duration is written as a string of hours and minutes like "26h 30m", which Jira reads
+// the same way regardless of its time tracking settings. It is read from a number of seconds or from
+// such a string, using the settings of DefaultTimeTrackingConfiguration for weeks and days.
+// TimeTracking and WorklogRecord read their durations from the seconds Jira returns along with the strings,
+// so they do not depend on the time tracking settings of the instance.
+type Duration time.Duration
+
+// Seconds returns the duration in whole seconds, as used by the *Seconds fields of Jira.
+func (d Duration) Seconds() int {
+	return int(time.Duration(d) / time.Second)
+}
+
+// String formats the duration in hours and minutes, e.g. "26h 30m".
+// Use TimeTrackingConfiguration.FormatDuration to format it with the weeks and days of a Jira instance.
+func (d Duration) String() string {
+	return d.hoursAndMinutes()
+}
+
+// MarshalJSON writes the duration as a string of hours and minutes.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.hoursAndMinutes())
+}
+
+// UnmarshalJSON reads the duration from a Jira duration string or a number of seconds.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	var seconds float64
+	if err := json.Unmarshal(b, &seconds); err == nil {
+		*d = Duration(seconds * float64(time.Second))
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// hoursAndMinutes formats the duration without weeks and days, which does not depend on the time tracking settings
+func (d Duration) hoursAndMinutes() string {
+	return formatDurationUnits(d, []durationUnit{{"h", time.Hour}, {"m", time.Minute}})
+}
+
+// SecondsDuration converts a number of seconds, e.g. TimeTracking.TimeSpentSeconds, to a Duration.
+func SecondsDuration(seconds int) Duration {
+	return Duration(time.Duration(seconds) * time.Second)
+}
+
+// ParseDuration parses a Jira duration like "1w 2d 3h 30m" using DefaultTimeTrackingConfiguration.
+func ParseDuration(s string) (Duration, error) {
+	return defaultTimeTrackingConfiguration.ParseDuration(s)
+}
+
+type durationUnit struct {
+	suffix string
+	length time.Duration
+}
+
+// units returns the units of Jira durations from the largest to the smallest
+func (c *TimeTrackingConfiguration) units() []durationUnit {
+	hoursPerDay := c.WorkingHoursPerDay
+	if hoursPerDay <= 0 {
+		hoursPerDay = defaultTimeTrackingConfiguration.WorkingHoursPerDay
+	}
+	daysPerWeek := c.WorkingDaysPerWeek
+	if daysPerWeek <= 0 {
+		daysPerWeek = defaultTimeTrackingConfiguration.WorkingDaysPerWeek
+	}
+	day := time.Duration(hoursPerDay * float64(time.Hour))
+	return []durationUnit{
+		{"w", time.Duration(daysPerWeek * float64(day))},
+		{"d", day},
+		{"h", time.Hour},
+		{"m", time.Minute},
+	}
+}
+
+func (c *TimeTrackingConfiguration) defaultUnit() time.Duration {
+	switch c.DefaultUnit {
+	case "week":
+		return c.units()[0].length
+	case "day":
+		return c.units()[1].length
+	case "hour":
+		return time.Hour
+	}
+	return time.Minute
+}
+
+// ParseDuration parses a Jira duration like "1w 2d 3h 30m", "1.5h" or "90".
+// Numbers without a unit are in the default unit, which is minutes unless configured otherwise.
+func (c *TimeTrackingConfiguration) ParseDuration(s string) (Duration, error) {
+	units := c.units()
+	input := strings.TrimSpace(s)
+	if input == "" {
+		return 0, fmt.Errorf("invalid duration %q: empty", s)
+	}
+
+	var total time.Duration
+	for input != "" {
+		end := strings.IndexFunc(input, func(r rune) bool { return !unicode.IsDigit(r) && r != '.' })
+		if end == 0 {
+			return 0, fmt.Errorf("invalid duration %q: expected a number at %q", s, input)
+		}
+		if end < 0 {
+			end = len(input)
+		}
+		value, err := strconv.ParseFloat(input[:end], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		input = strings.TrimLeftFunc(input[end:], unicode.IsSpace)
+
+		unit := c.defaultUnit()
+		if input != "" && unicode.IsLetter(rune(input[0])) {
+			found := false
+			for _, u := range units {
+				if strings.HasPrefix(strings.ToLower(input), u.suffix) {
+					unit, found = u.length, true
+					input = input[len(u.suffix):]
+					break
+				}
+			}
+			if !found {
+				return 0, fmt.Errorf("invalid duration %q: unknown unit at %q", s, input)
+			}
+		}
+		total += time.Duration(value * float64(unit))
+		input = strings.TrimLeftFunc(input, unicode.IsSpace)
+	}
+
+	return Duration(total.Round(time.Second)), nil
+}
+
+// FormatDuration formats a duration like Jira, e.g. "1w 2d 3h 30m".
+// Seconds are rounded to the nearest minute; a zero duration is formatted as "0m".
+func (c *TimeTrackingConfiguration) FormatDuration(d Duration) string {
+	return formatDurationUnits(d, c.units())
+}
+
+func formatDurationUnits(d Duration, units []durationUnit) string {
+	remaining := time.Duration(d).Round(time.Minute)
+	sign := ""
+	if remaining < 0 {
+		sign, remaining = "-", -remaining
+	}
+
+	var parts []string
+	for _, u := range units {
+		if n := remaining / u.length; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, u.suffix))
+			remaining -= n * u.length
+		}
+	}
+	if len(parts) == 0 {
+		return "0m"
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+// jsonDuration returns a duration of TimeTracking or WorklogRecord read from JSON.
+// Jira returns the seconds along with the formatted string, which only needs to be parsed if they are missing.
+func jsonDuration(formatted string, seconds int) (Duration, error) {
+	if seconds != 0 || formatted == "" {
+		return SecondsDuration(seconds), nil
+	}
+	return ParseDuration(formatted)
+}
+
+// UnmarshalJSON reads the durations of the time tracking from their seconds.
+func (t *TimeTracking) UnmarshalJSON(data []byte) error {
+	type Alias TimeTracking
+	aux := &struct {
+		*Alias
+		OriginalEstimate  string `json:"originalEstimate"`
+		RemainingEstimate string `json:"remainingEstimate"`
+		TimeSpent         string `json:"timeSpent"`
+	}{
+		Alias: (*Alias)(t),
+	}
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+
+	var err error
+	if t.OriginalEstimate, err = jsonDuration(aux.OriginalEstimate, t.OriginalEstimateSeconds); err != nil {
+		return err
+	}
+	if t.RemainingEstimate, err = jsonDuration(aux.RemainingEstimate, t.RemainingEstimateSeconds); err != nil {
+		return err
+	}
+	t.TimeSpent, err = jsonDuration(aux.TimeSpent, t.TimeSpentSeconds)
+	return err
+}
+
+// SetOriginalEstimate sets the original estimate and its seconds.
+func (t *TimeTracking) SetOriginalEstimate(d Duration) {
+	t.OriginalEstimate = d
+	t.OriginalEstimateSeconds = d.Seconds()
+}
+
+// SetRemainingEstimate sets the remaining estimate and its seconds.
+func (t *TimeTracking) SetRemainingEstimate(d Duration) {
+	t.RemainingEstimate = d
+	t.RemainingEstimateSeconds = d.Seconds()
+}
+
+// UnmarshalJSON reads the time spent of the worklog from its seconds.
+func (w *WorklogRecord) UnmarshalJSON(data []byte) error {
+	type Alias WorklogRecord
+	aux := &struct {
+		*Alias
+		TimeSpent string `json:"timeSpent"`
+	}{
+		Alias: (*Alias)(w),
+	}
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+
+	var err error
+	w.TimeSpent, err = jsonDuration(aux.TimeSpent, w.TimeSpentSeconds)
+	return err
+}
+
+// SetTimeSpent sets the time spent and its seconds.
+func (w *WorklogRecord) SetTimeSpent(d Duration) {
+	w.TimeSpent = d
+	w.TimeSpentSeconds = d.Seconds()
+}
@@ -0,0 +1,150 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTimeTrackingConfiguration_ParseDuration(t *testing.T) {
+	c := TimeTrackingConfiguration{WorkingHoursPerDay: 8, WorkingDaysPerWeek: 5}
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"1w 2d 3h 30m", 40*time.Hour + 16*time.Hour + 3*time.Hour + 30*time.Minute},
+		{"3h30m", 3*time.Hour + 30*time.Minute},
+		{"1.5h", 90 * time.Minute},
+		{"90", 90 * time.Minute},
+		{" 2D ", 16 * time.Hour},
+	}
+	for _, tt := range tests {
+		got, err := c.ParseDuration(tt.in)
+		if err != nil {
+			t.Errorf("ParseDuration(%q): error given: %s", tt.in, err)
+			continue
+		}
+		if time.Duration(got) != tt.want {
+			t.Errorf("ParseDuration(%q) = %v, want %v", tt.in, time.Duration(got), tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "h", "3x", "1w 2y"} {
+		if _, err := c.ParseDuration(in); err == nil {
+			t.Errorf("ParseDuration(%q): expected an error", in)
+		}
+	}
+}
+
+func TestTimeTrackingConfiguration_FormatDuration(t *testing.T) {
+	c := TimeTrackingConfiguration{WorkingHoursPerDay: 7.5, WorkingDaysPerWeek: 5}
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{0, "0m"},
+		{45 * time.Second, "1m"},
+		{7*time.Hour + 30*time.Minute, "1d"},
+		{37*time.Hour + 30*time.Minute + 8*time.Hour + 30*time.Minute, "1w 1d 1h"},
+		{-90 * time.Minute, "-1h 30m"},
+	}
+	for _, tt := range tests {
+		if got := c.FormatDuration(Duration(tt.in)); got != tt.want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDuration_JSON(t *testing.T) {
+	var v struct {
+		A Duration `json:"a"`
+		B Duration `json:"b"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":"1d 2h","b":5400}`), &v); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if time.Duration(v.A) != 10*time.Hour || time.Duration(v.B) != 90*time.Minute {
+		t.Errorf("Unexpected durations %v, %v", time.Duration(v.A), time.Duration(v.B))
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if string(b) != `{"a":"10h","b":"1h 30m"}` {
+		t.Errorf("Unexpected JSON %s", b)
+	}
+	if v.A.String() != "10h" {
+		t.Errorf("Expected 10h, got %s", v.A)
+	}
+}
+
+func TestTimeTracking_Durations(t *testing.T) {
+	// An instance with 7.5 hour days: the formatted strings differ from the defaults, the seconds do not
+	var fields IssueFields
+	data := `{"timetracking":{"originalEstimate":"1d","originalEstimateSeconds":27000,"timeSpent":"2h","timeSpentSeconds":7200},
+		"worklog":{"worklogs":[{"timeSpent":"1d","timeSpentSeconds":27000}]}}`
+	if err := json.Unmarshal([]byte(data), &fields); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if fields.TimeTracking.OriginalEstimate != Duration(450*time.Minute) || fields.TimeTracking.TimeSpent != Duration(2*time.Hour) {
+		t.Errorf("Unexpected time tracking %+v", fields.TimeTracking)
+	}
+	if fields.Worklog.Worklogs[0].TimeSpent != Duration(450*time.Minute) {
+		t.Errorf("Unexpected worklog %+v", fields.Worklog.Worklogs[0])
+	}
+
+	var tt TimeTracking
+	if err := json.Unmarshal([]byte(`{"remainingEstimate":"1d 4h"}`), &tt); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if tt.RemainingEstimate != Duration(12*time.Hour) {
+		t.Errorf("Expected 12h remaining, got %v", tt.RemainingEstimate)
+	}
+
+	update := &IssueFields{TimeTracking: &TimeTracking{}}
+	update.TimeTracking.SetOriginalEstimate(Duration(26 * time.Hour))
+	b, err := json.Marshal(update)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if want := `{"timetracking":{"originalEstimate":"26h","originalEstimateSeconds":93600}}`; string(b) != want {
+		t.Errorf("Expected JSON %s, got %s", want, b)
+	}
+
+	var w WorklogRecord
+	w.SetTimeSpent(Duration(150 * time.Minute))
+	if w.TimeSpent != Duration(150*time.Minute) || w.TimeSpentSeconds != 9000 {
+		t.Errorf("Unexpected time spent %v / %d", w.TimeSpent, w.TimeSpentSeconds)
+	}
+}
+
+func TestConfigurationService_GetTimeTrackingConfiguration(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/configuration", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testRequestURL(t, r, "/rest/api/2/configuration")
+
+		fmt.Fprint(w, `{"votingEnabled":true,"timeTrackingEnabled":true,
+			"timeTrackingConfiguration":{"workingHoursPerDay":7.5,"workingDaysPerWeek":4.0,"timeFormat":"pretty","defaultUnit":"hour"}}`)
+	})
+
+	c, _, err := testClient.Configuration.GetTimeTrackingConfiguration(context.Background())
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if c.WorkingHoursPerDay != 7.5 || c.WorkingDaysPerWeek != 4 {
+		t.Errorf("Unexpected configuration %+v", c)
+	}
+	d, err := c.ParseDuration("1w 2")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if time.Duration(d) != 32*time.Hour {
+		t.Errorf("Expected 32h, got %v", time.Duration(d))
+	}
+}
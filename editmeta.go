@@ -0,0 +1,197 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Operations on a field in an edit request, as listed in the edit metadata of the field.
+const (
+	EditOperationSet    = "set"
+	EditOperationAdd    = "add"
+	EditOperationRemove = "remove"
+	EditOperationEdit   = "edit"
+)
+
+// FieldOperation is an operation on a field in an edit request, like adding a label.
+// Use it as value of the changes passed to EditMetaInfo.EditRequest.
+type FieldOperation struct {
+	// Verb is one of EditOperationSet, EditOperationAdd, EditOperationRemove and EditOperationEdit.
+	Verb  string
+	Value interface{}
+}
+
+// HasOperation reports whether the field supports the operation, e.g. EditOperationAdd.
+func (f *ProjectIssueField) HasOperation(verb string) bool {
+	for _, op := range f.Operations {
+		if op == verb {
+			return true
+		}
+	}
+	return false
+}
+
+// EditableFields returns the fields which can be edited, keyed by field ID.
+func (m *EditMetaInfo) EditableFields() (map[string]ProjectIssueField, error) {
+	return decodeMetaFields(m.Fields)
+}
+
+// Field returns the editable field with the given ID or, case insensitive, name. If not found, this returns nil.
+func (m *EditMetaInfo) Field(idOrName string) (*ProjectIssueField, error) {
+	fields, err := m.EditableFields()
+	if err != nil {
+		return nil, err
+	}
+	if f, ok := findMetaField(fields, idOrName); ok {
+		return &f, nil
+	}
+	return nil, nil
+}
+
+// CanEdit checks whether the changes can be applied to the issue: every field must be editable, support the
+// operation and the values must match the field schema and allowed values.
+// The changes are keyed by field ID or name, see EditRequest. All violations are returned at once as *ValidationError.
+func (m *EditMetaInfo) CanEdit(changes map[string]interface{}) error {
+	_, err := m.EditRequest(changes)
+	return err
+}
+
+// EditRequest converts the changes to an edit request for IssueService.UpdateIssue.
+// The changes are keyed by field ID or name. A value is either a new field value, a FieldOperation or a
+// []FieldOperation. New values are sent in "fields" if the field supports EditOperationSet; values of array
+// fields which only support EditOperationAdd, like the comments, are added item by item in "update".
+// Operations are always sent in "update".
+//
+// The changes are checked like CanEdit and a *ValidationError is returned if any is invalid.
+func (m *EditMetaInfo) EditRequest(changes map[string]interface{}) (map[string]interface{}, error) {
+	editable, err := m.EditableFields()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(changes))
+	for name := range changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make(map[string]interface{})
+	update := make(map[string][]map[string]interface{})
+	var violations []FieldViolation
+	for _, name := range names {
+		field, ok := findMetaField(editable, name)
+		if !ok {
+			violations = append(violations, FieldViolation{FieldID: name, Name: name, Message: "is not editable"})
+			continue
+		}
+		violate := func(format string, args ...interface{}) {
+			violations = append(violations, FieldViolation{FieldID: field.FieldID, Name: field.Name, Message: fmt.Sprintf(format, args...)})
+		}
+
+		var ops []FieldOperation
+		switch change := changes[name].(type) {
+		case FieldOperation:
+			ops = []FieldOperation{change}
+		case []FieldOperation:
+			ops = change
+		default:
+			value, err := normalizeFieldValue(change)
+			if err != nil {
+				return nil, err
+			}
+			items, isList := value.([]interface{})
+			switch {
+			case field.HasOperation(EditOperationSet):
+				if isEmptyFieldValue(value) {
+					if field.Required {
+						violate("is required")
+					}
+				} else {
+					for _, message := range validateFieldValue(&field, value) {
+						violate("%s", message)
+					}
+				}
+				fields[field.FieldID] = value
+				continue
+			case field.HasOperation(EditOperationAdd) && isList:
+				for _, item := range items {
+					ops = append(ops, FieldOperation{Verb: EditOperationAdd, Value: item})
+				}
+			default:
+				violate("cannot be set; supported operations are %s", strings.Join(field.Operations, ", "))
+				continue
+			}
+		}
+
+		for _, op := range ops {
+			if !field.HasOperation(op.Verb) {
+				violate("does not support %q; supported operations are %s", op.Verb, strings.Join(field.Operations, ", "))
+				continue
+			}
+			value, err := normalizeFieldValue(op.Value)
+			if err != nil {
+				return nil, err
+			}
+			switch op.Verb {
+			case EditOperationSet:
+				if !isEmptyFieldValue(value) {
+					for _, message := range validateFieldValue(&field, value) {
+						violate("%s", message)
+					}
+				}
+			case EditOperationAdd, EditOperationRemove:
+				schemaType := field.Schema.Type
+				if schemaType == "array" {
+					schemaType = field.Schema.Items
+				}
+				for _, message := range validateSchemaValue(&field, schemaType, value) {
+					violate("%s %s", op.Verb, message)
+				}
+			}
+			update[field.FieldID] = append(update[field.FieldID], map[string]interface{}{op.Verb: value})
+		}
+	}
+
+	if len(violations) > 0 {
+		return nil, &ValidationError{Violations: violations}
+	}
+	request := make(map[string]interface{})
+	if len(fields) > 0 {
+		request["fields"] = fields
+	}
+	if len(update) > 0 {
+		request["update"] = update
+	}
+	return request, nil
+}
+
+// normalizeFieldValue converts a value to the types of decoded JSON, as expected by the validation
+func normalizeFieldValue(value interface{}) (interface{}, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// EditIssue fetches the edit metadata of the issue, converts the changes with EditMetaInfo.EditRequest
+// and applies them. Nothing is changed if any change is invalid.
+// Caller must close resp.Body
+func (s *IssueService) EditIssue(ctx context.Context, issueID string, changes map[string]interface{}) (*Response, error) {
+	meta, resp, err := s.GetEditMeta(ctx, &Issue{Key: issueID})
+	if err != nil {
+		return resp, err
+	}
+	request, err := meta.EditRequest(changes)
+	if err != nil {
+		return nil, err
+	}
+	return s.UpdateIssue(ctx, issueID, request)
+}
@@ -0,0 +1,148 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const editMetaTestResponse = `{"fields":{
+	"summary":{"required":true,"name":"Summary","schema":{"type":"string","system":"summary"},"operations":["set"]},
+	"labels":{"required":false,"name":"Labels","schema":{"type":"array","items":"string","system":"labels"},"operations":["add","set","remove"]},
+	"comment":{"required":false,"name":"Comment","schema":{"type":"comments-page","system":"comment"},"operations":["add","edit","remove"]},
+	"fixVersions":{"required":false,"name":"Fix Version/s","schema":{"type":"array","items":"version","system":"fixVersions"},"operations":["add"],
+		"allowedValues":[{"id":"10","name":"1.0"},{"id":"11","name":"1.1"}]},
+	"customfield_10006":{"required":false,"name":"Team","schema":{"type":"option"},"operations":["set"],"allowedValues":[{"id":"30","value":"Red"}]}
+}}`
+
+func editMetaTestInfo(t *testing.T) *EditMetaInfo {
+	meta := new(EditMetaInfo)
+	if err := json.Unmarshal([]byte(editMetaTestResponse), meta); err != nil {
+		t.Fatal(err)
+	}
+	return meta
+}
+
+func TestEditMetaInfo_Field(t *testing.T) {
+	meta := editMetaTestInfo(t)
+
+	field, err := meta.Field("team")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if field == nil || field.FieldID != "customfield_10006" || !field.HasOperation(EditOperationSet) || field.HasOperation(EditOperationAdd) {
+		t.Errorf("Unexpected field %+v", field)
+	}
+	if len(field.AllowedValues) != 1 || field.AllowedValues[0].Value != "Red" {
+		t.Errorf("Unexpected allowed values %+v", field.AllowedValues)
+	}
+
+	if field, _ := meta.Field("Assignee"); field != nil {
+		t.Errorf("Expected no field, got %+v", field)
+	}
+
+	fields, err := meta.EditableFields()
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(fields) != 5 {
+		t.Errorf("Expected 5 editable fields, got %d", len(fields))
+	}
+}
+
+func TestEditMetaInfo_EditRequest(t *testing.T) {
+	meta := editMetaTestInfo(t)
+
+	request, err := meta.EditRequest(map[string]interface{}{
+		"Summary":           "New summary",
+		"labels":            FieldOperation{Verb: EditOperationAdd, Value: "backend"},
+		"comment":           FieldOperation{Verb: EditOperationAdd, Value: map[string]string{"body": "Edited"}},
+		"fixVersions":       []map[string]string{{"name": "1.1"}},
+		"customfield_10006": map[string]string{"value": "Red"},
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	want := map[string]interface{}{
+		"fields": map[string]interface{}{
+			"summary":           "New summary",
+			"customfield_10006": map[string]interface{}{"value": "Red"},
+		},
+		"update": map[string][]map[string]interface{}{
+			"labels":      {{"add": "backend"}},
+			"comment":     {{"add": map[string]interface{}{"body": "Edited"}}},
+			"fixVersions": {{"add": map[string]interface{}{"name": "1.1"}}},
+		},
+	}
+	if !reflect.DeepEqual(request, want) {
+		t.Errorf("Unexpected request:\n got %v\nwant %v", request, want)
+	}
+}
+
+func TestEditMetaInfo_CanEdit(t *testing.T) {
+	meta := editMetaTestInfo(t)
+
+	err := meta.CanEdit(map[string]interface{}{
+		"summary":           "",
+		"comment":           map[string]string{"body": "Set is not supported"},
+		"labels":            FieldOperation{Verb: EditOperationEdit, Value: "x"},
+		"fixVersions":       []map[string]string{{"name": "2.0"}},
+		"customfield_10006": map[string]string{"value": "Blue"},
+		"assignee":          map[string]string{"name": "bob"},
+	})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+	got := map[string]bool{}
+	for _, v := range validationErr.Violations {
+		got[v.FieldID] = true
+	}
+	for _, id := range []string{"summary", "comment", "labels", "fixVersions", "customfield_10006", "assignee"} {
+		if !got[id] {
+			t.Errorf("Expected a violation of %s, got %v", id, validationErr.Violations)
+		}
+	}
+
+	if err := meta.CanEdit(map[string]interface{}{"labels": []string{"a", "b"}}); err != nil {
+		t.Errorf("Expected a valid edit, got %s", err)
+	}
+}
+
+func TestIssueService_EditIssue(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/PROJ-1/editmeta", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, editMetaTestResponse)
+	})
+	var body map[string]interface{}
+	testMux.HandleFunc("/rest/api/2/issue/PROJ-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := testClient.Issue.EditIssue(context.Background(), "PROJ-1", map[string]interface{}{"Labels": []string{"a"}}); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	want := map[string]interface{}{"fields": map[string]interface{}{"labels": []interface{}{"a"}}}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("Expected %v, got %v", want, body)
+	}
+
+	body = nil
+	if _, err := testClient.Issue.EditIssue(context.Background(), "PROJ-1", map[string]interface{}{"Reporter": "bob"}); err == nil {
+		t.Error("Expected an error for a field which is not editable")
+	}
+	if body != nil {
+		t.Error("Expected no update request for an invalid edit")
+	}
+}
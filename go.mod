@@ -9,6 +9,7 @@ require (
 	github.com/google/go-querystring v1.1.0
 	github.com/trivago/tgo v1.0.7
 	golang.org/x/term v0.0.0-20210220032956-6a3ed077a48d
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require golang.org/x/sys v0.0.0-20220330033206-e17cdc41300f // indirect
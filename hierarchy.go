@@ -0,0 +1,275 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// HierarchyOptions specifies the optional parameters to IssueService.GetHierarchy and IssueService.FindOrphans
+type HierarchyOptions struct {
+	// EpicLinkField is the ID of the "Epic Link" custom field of Jira Server / Data Center, e.g. "customfield_10006".
+	// If empty, the children of epics are searched by the field name and orphans are not detected by epic link.
+	EpicLinkField string
+	// ParentLinkField is the ID of the Advanced Roadmaps "Parent Link" custom field, which links epics to initiatives.
+	// If empty, parent links are not followed. Parent links are only followed from issues of RootIssueTypes.
+	ParentLinkField string
+	// EpicIssueType is the name of the epic issue type. Default: "Epic".
+	EpicIssueType string
+	// RootIssueTypes are the issue types at the top of the hierarchy, which are never orphans.
+	// Default: the issue type "Initiative" if ParentLinkField is set, otherwise EpicIssueType.
+	RootIssueTypes []string
+	// MaxDepth limits the depth of the tree below the root. Default: unlimited.
+	MaxDepth int
+	// Concurrency is the maximum number of concurrent requests. Default: 4.
+	Concurrency int
+	// Fields are returned in addition to the fields needed to build the hierarchy.
+	Fields []string
+}
+
+func (o *HierarchyOptions) withDefaults() *HierarchyOptions {
+	opts := HierarchyOptions{}
+	if o != nil {
+		opts = *o
+	}
+	if opts.EpicIssueType == "" {
+		opts.EpicIssueType = "Epic"
+	}
+	if len(opts.RootIssueTypes) == 0 {
+		if opts.ParentLinkField != "" {
+			opts.RootIssueTypes = []string{"Initiative"}
+		} else {
+			opts.RootIssueTypes = []string{opts.EpicIssueType}
+		}
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+	return &opts
+}
+
+func (o *HierarchyOptions) fields() []string {
+	fields := []string{
+		"summary", "status", "issuetype", "parent", "subtasks",
+		"timeoriginalestimate", "timeestimate", "timespent",
+	}
+	if o.EpicLinkField != "" {
+		fields = append(fields, o.EpicLinkField)
+	}
+	if o.ParentLinkField != "" {
+		fields = append(fields, o.ParentLinkField)
+	}
+	return append(fields, o.Fields...)
+}
+
+// hierarchyClause returns the JQL clause for a custom field, preferring its ID over its name.
+func hierarchyClause(fieldID, fieldName string) string {
+	if id := strings.TrimPrefix(fieldID, "customfield_"); id != fieldID {
+		return fmt.Sprintf("cf[%s]", id)
+	}
+	return fmt.Sprintf("%q", fieldName)
+}
+
+// childrenJQL returns the JQL query finding the direct children of an issue
+func (o *HierarchyOptions) childrenJQL(issue *Issue) string {
+	clauses := []string{fmt.Sprintf("parent = %s", issue.Key)}
+	if issue.Fields != nil && !issue.Fields.Type.Subtask {
+		if strings.EqualFold(issue.Fields.Type.Name, o.EpicIssueType) {
+			clauses = append(clauses, fmt.Sprintf("%s = %s", hierarchyClause(o.EpicLinkField, "Epic Link"), issue.Key))
+		} else if o.ParentLinkField != "" && o.isRootType(issue.Fields.Type.Name) {
+			clauses = append(clauses, fmt.Sprintf("%s = %s", hierarchyClause(o.ParentLinkField, "Parent Link"), issue.Key))
+		}
+	}
+	return strings.Join(clauses, " OR ")
+}
+
+// IssueNode is an issue within an issue hierarchy, e.g. Initiative > Epic > Story > Sub-task.
+type IssueNode struct {
+	Issue    Issue
+	Parent   *IssueNode `json:"-"`
+	Children []*IssueNode
+	// Depth is the distance to the root of the tree. The root has depth 0.
+	Depth int
+}
+
+// Walk calls f for the node and all its descendants in depth-first order.
+// Walking stops at the first error returned by f.
+func (n *IssueNode) Walk(f func(*IssueNode) error) error {
+	if err := f(n); err != nil {
+		return err
+	}
+	for _, child := range n.Children {
+		if err := child.Walk(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Find returns the node of the issue with the given key, or nil if the issue is not part of the tree.
+func (n *IssueNode) Find(key string) *IssueNode {
+	var found *IssueNode
+	_ = n.Walk(func(node *IssueNode) error {
+		if node.Issue.Key == key {
+			found = node
+			return errStopWalk
+		}
+		return nil
+	})
+	return found
+}
+
+var errStopWalk = fmt.Errorf("stop walk")
+
+// HierarchyRollup is the aggregate of an issue and all its descendants.
+// Estimates and time spent are in seconds.
+type HierarchyRollup struct {
+	Issues            int
+	OriginalEstimate  int
+	RemainingEstimate int
+	TimeSpent         int
+	// StatusCategories counts the issues per status category key.
+	StatusCategories map[string]int
+}
+
+// Rollup aggregates estimates, time spent and status categories of the node and all its descendants.
+// Only the own values of every issue are summed, so sub-tasks are not counted twice.
+func (n *IssueNode) Rollup() HierarchyRollup {
+	r := HierarchyRollup{StatusCategories: make(map[string]int)}
+	_ = n.Walk(func(node *IssueNode) error {
+		r.Issues++
+		fields := node.Issue.Fields
+		if fields == nil {
+			return nil
+		}
+		r.OriginalEstimate += fields.TimeOriginalEstimate
+		r.RemainingEstimate += fields.TimeEstimate
+		r.TimeSpent += fields.TimeSpent
+		if fields.Status != nil {
+			r.StatusCategories[fields.Status.StatusCategory.Key]++
+		}
+		return nil
+	})
+	return r
+}
+
+// GetHierarchy fetches the issue with the given key and all its descendants.
+// Children are found through parent links of sub-tasks, the epic link of epics and, if configured,
+// the Advanced Roadmaps parent link. Every level of the tree is fetched with at most options.Concurrency requests at once.
+func (s *IssueService) GetHierarchy(ctx context.Context, issueKey string, options *HierarchyOptions) (*IssueNode, error) {
+	opts := options.withDefaults()
+	fields := opts.fields()
+
+	root, _, err := s.Get(ctx, issueKey, &GetQueryOptions{Fields: strings.Join(fields, ",")})
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &IssueNode{Issue: *root}
+	visited := map[string]bool{root.Key: true}
+	level := []*IssueNode{tree}
+
+	for depth := 1; len(level) > 0 && (opts.MaxDepth <= 0 || depth <= opts.MaxDepth); depth++ {
+		children := make([][]Issue, len(level))
+		errs := make([]error, len(level))
+		sem := make(chan struct{}, opts.Concurrency)
+		var wg sync.WaitGroup
+
+		for i, node := range level {
+			wg.Add(1)
+			go func(i int, node *IssueNode) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				jql := opts.childrenJQL(&node.Issue)
+				errs[i] = s.SearchPages(ctx, jql, &SearchOptions{MaxResults: 50, Fields: fields}, func(issue Issue) error {
+					children[i] = append(children[i], issue)
+					return nil
+				})
+			}(i, node)
+		}
+		wg.Wait()
+
+		var next []*IssueNode
+		for i, node := range level {
+			if errs[i] != nil {
+				return nil, errs[i]
+			}
+			for _, issue := range children[i] {
+				if visited[issue.Key] {
+					continue
+				}
+				visited[issue.Key] = true
+				child := &IssueNode{Issue: issue, Parent: node, Depth: depth}
+				node.Children = append(node.Children, child)
+				next = append(next, child)
+			}
+		}
+		level = next
+	}
+
+	return tree, nil
+}
+
+// FindOrphans returns the issues matching jql which are expected to have a parent but have none:
+// sub-tasks are never orphans, issues of options.RootIssueTypes are never orphans, and every other issue
+// needs an epic link, a parent link or a parent.
+// options.EpicLinkField must be set on Jira Server / Data Center for epic links to be detected.
+func (s *IssueService) FindOrphans(ctx context.Context, jql string, options *HierarchyOptions) ([]Issue, error) {
+	opts := options.withDefaults()
+
+	var orphans []Issue
+	err := s.SearchPages(ctx, jql, &SearchOptions{MaxResults: 50, Fields: opts.fields()}, func(issue Issue) error {
+		if opts.isOrphan(&issue) {
+			orphans = append(orphans, issue)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return orphans, nil
+}
+
+func (o *HierarchyOptions) isOrphan(issue *Issue) bool {
+	fields := issue.Fields
+	if fields == nil || fields.Type.Subtask {
+		return false
+	}
+	if o.isRootType(fields.Type.Name) {
+		return false
+	}
+	if fields.Parent != nil && (fields.Parent.Key != "" || fields.Parent.ID != "") {
+		return false
+	}
+	if strings.EqualFold(fields.Type.Name, o.EpicIssueType) {
+		return !hasHierarchyLink(fields.Unknowns[o.ParentLinkField])
+	}
+	return !hasHierarchyLink(fields.Unknowns[o.EpicLinkField])
+}
+
+func (o *HierarchyOptions) isRootType(issueType string) bool {
+	for _, t := range o.RootIssueTypes {
+		if strings.EqualFold(issueType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasHierarchyLink reports whether the value of an epic link or parent link field references an issue.
+// The epic link is the key of the epic, while the parent link is an object with a "data" object holding the key.
+func hasHierarchyLink(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return v != ""
+	case map[string]interface{}:
+		if data, ok := v["data"].(map[string]interface{}); ok {
+			return data["key"] != nil || data["id"] != nil
+		}
+		return v["key"] != nil || v["id"] != nil
+	}
+	return false
+}
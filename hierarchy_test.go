@@ -0,0 +1,119 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestIssueService_GetHierarchy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/rest/api/2/issue/INIT-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"key":"INIT-1","fields":{"issuetype":{"name":"Initiative"},"status":{"statusCategory":{"key":"indeterminate"}}}}`)
+	})
+
+	children := map[string]string{
+		`parent = INIT-1 OR cf[10100] = INIT-1`: `[{"key":"EPIC-1","fields":{"issuetype":{"name":"Epic"},"status":{"statusCategory":{"key":"indeterminate"}}}}]`,
+		`parent = EPIC-1 OR cf[10006] = EPIC-1`: `[{"key":"STORY-1","fields":{"issuetype":{"name":"Story"},"timeoriginalestimate":7200,"timespent":3600,"status":{"statusCategory":{"key":"done"}}}},
+			{"key":"STORY-2","fields":{"issuetype":{"name":"Story"},"timeoriginalestimate":3600,"status":{"statusCategory":{"key":"new"}}}}]`,
+		`parent = STORY-1`: `[{"key":"SUB-1","fields":{"issuetype":{"name":"Sub-task","subtask":true},"timespent":1800,"status":{"statusCategory":{"key":"done"}}}}]`,
+		`parent = STORY-2`: `[]`,
+		`parent = SUB-1`:   `[]`,
+	}
+	testMux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		jql := r.URL.Query().Get("jql")
+		issues, ok := children[jql]
+		if !ok {
+			t.Errorf("Unexpected JQL %q", jql)
+			issues = `[]`
+		}
+		fmt.Fprintf(w, `{"startAt":0,"maxResults":50,"total":2,"issues":%s}`, issues)
+	})
+
+	tree, err := testClient.Issue.GetHierarchy(context.Background(), "INIT-1", &HierarchyOptions{
+		EpicLinkField:   "customfield_10006",
+		ParentLinkField: "customfield_10100",
+		Concurrency:     2,
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	if len(tree.Children) != 1 || tree.Children[0].Issue.Key != "EPIC-1" {
+		t.Fatalf("Expected EPIC-1 below the initiative, got %+v", tree.Children)
+	}
+	if len(tree.Children[0].Children) != 2 {
+		t.Fatalf("Expected 2 stories below the epic, got %d", len(tree.Children[0].Children))
+	}
+	sub := tree.Find("SUB-1")
+	if sub == nil || sub.Depth != 3 || sub.Parent.Issue.Key != "STORY-1" {
+		t.Fatalf("Expected SUB-1 below STORY-1 at depth 3, got %+v", sub)
+	}
+
+	rollup := tree.Rollup()
+	if rollup.Issues != 5 {
+		t.Errorf("Expected 5 issues, got %d", rollup.Issues)
+	}
+	if rollup.OriginalEstimate != 10800 || rollup.TimeSpent != 5400 {
+		t.Errorf("Expected estimate 10800 and time spent 5400, got %d and %d", rollup.OriginalEstimate, rollup.TimeSpent)
+	}
+	if rollup.StatusCategories[StatusCategoryComplete] != 2 || rollup.StatusCategories[StatusCategoryInProgress] != 2 {
+		t.Errorf("Unexpected status categories %v", rollup.StatusCategories)
+	}
+}
+
+func TestIssueService_GetHierarchy_MaxDepth(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/rest/api/2/issue/EPIC-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"key":"EPIC-1","fields":{"issuetype":{"name":"Epic"}}}`)
+	})
+	searches := 0
+	testMux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		searches++
+		if jql := r.URL.Query().Get("jql"); jql != `parent = EPIC-1 OR "Epic Link" = EPIC-1` {
+			t.Errorf("Unexpected JQL %q", jql)
+		}
+		fmt.Fprint(w, `{"startAt":0,"maxResults":50,"total":1,"issues":[{"key":"STORY-1","fields":{"issuetype":{"name":"Story"}}}]}`)
+	})
+
+	tree, err := testClient.Issue.GetHierarchy(context.Background(), "EPIC-1", &HierarchyOptions{MaxDepth: 1})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if searches != 1 {
+		t.Errorf("Expected a single search, got %d", searches)
+	}
+	if len(tree.Children) != 1 || len(tree.Children[0].Children) != 0 {
+		t.Errorf("Expected a single level of children, got %+v", tree.Children)
+	}
+}
+
+func TestIssueService_FindOrphans(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"startAt":0,"maxResults":50,"total":5,"issues":[
+			{"key":"EPIC-1","fields":{"issuetype":{"name":"Epic"}}},
+			{"key":"STORY-1","fields":{"issuetype":{"name":"Story"},"customfield_10006":"EPIC-1"}},
+			{"key":"STORY-2","fields":{"issuetype":{"name":"Story"},"customfield_10006":null}},
+			{"key":"STORY-3","fields":{"issuetype":{"name":"Story"},"parent":{"key":"EPIC-1"}}},
+			{"key":"SUB-1","fields":{"issuetype":{"name":"Sub-task","subtask":true}}}]}`)
+	})
+
+	orphans, err := testClient.Issue.FindOrphans(context.Background(), "project = TEST", &HierarchyOptions{EpicLinkField: "customfield_10006"})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(orphans) != 1 || orphans[0].Key != "STORY-2" {
+		t.Errorf("Expected STORY-2 to be the only orphan, got %+v", orphans)
+	}
+}
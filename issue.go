@@ -1,12 +1,10 @@
 package jira
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -123,11 +121,11 @@ type IssueFields struct {
 	Status                        *Status           `json:"status,omitempty" structs:"status,omitempty"`
 	Progress                      *Progress         `json:"progress,omitempty" structs:"progress,omitempty"`
 	AggregateProgress             *Progress         `json:"aggregateprogress,omitempty" structs:"aggregateprogress,omitempty"`
-	TimeTracking                  *TimeTracking     `json:"timetracking,omitempty" structs:"timetracking,omitempty"`
+	TimeTracking                  *TimeTracking     `json:"timetracking,omitempty" structs:"timetracking,omitempty,omitnested"`
 	TimeSpent                     int               `json:"timespent,omitempty" structs:"timespent,omitempty"`
 	TimeEstimate                  int               `json:"timeestimate,omitempty" structs:"timeestimate,omitempty"`
 	TimeOriginalEstimate          int               `json:"timeoriginalestimate,omitempty" structs:"timeoriginalestimate,omitempty"`
-	Worklog                       *Worklog          `json:"worklog,omitempty" structs:"worklog,omitempty"`
+	Worklog                       *Worklog          `json:"worklog,omitempty" structs:"worklog,omitempty,omitnested"`
 	IssueLinks                    []*IssueLink      `json:"issuelinks,omitempty" structs:"issuelinks,omitempty"`
 	Comments                      *Comments         `json:"comment,omitempty" structs:"comment,omitempty"`
 	FixVersions                   []*FixVersion     `json:"fixVersions,omitempty" structs:"fixVersions,omitempty"`
@@ -414,7 +412,7 @@ type WorklogRecord struct {
 	Created          *Time            `json:"created,omitempty" structs:"created,omitempty"`
 	Updated          *Time            `json:"updated,omitempty" structs:"updated,omitempty"`
 	Started          *Time            `json:"started,omitempty" structs:"started,omitempty"`
-	TimeSpent        string           `json:"timeSpent,omitempty" structs:"timeSpent,omitempty"`
+	TimeSpent        Duration         `json:"timeSpent,omitempty" structs:"timeSpent,omitempty"`
 	TimeSpentSeconds int              `json:"timeSpentSeconds,omitempty" structs:"timeSpentSeconds,omitempty"`
 	ID               string           `json:"id,omitempty" structs:"id,omitempty"`
 	IssueID          string           `json:"issueId,omitempty" structs:"issueId,omitempty"`
@@ -428,12 +426,12 @@ type EntityProperty struct {
 
 // TimeTracking represents the timetracking fields of a Jira issue.
 type TimeTracking struct {
-	OriginalEstimate         string `json:"originalEstimate,omitempty" structs:"originalEstimate,omitempty"`
-	RemainingEstimate        string `json:"remainingEstimate,omitempty" structs:"remainingEstimate,omitempty"`
-	TimeSpent                string `json:"timeSpent,omitempty" structs:"timeSpent,omitempty"`
-	OriginalEstimateSeconds  int    `json:"originalEstimateSeconds,omitempty" structs:"originalEstimateSeconds,omitempty"`
-	RemainingEstimateSeconds int    `json:"remainingEstimateSeconds,omitempty" structs:"remainingEstimateSeconds,omitempty"`
-	TimeSpentSeconds         int    `json:"timeSpentSeconds,omitempty" structs:"timeSpentSeconds,omitempty"`
+	OriginalEstimate         Duration `json:"originalEstimate,omitempty" structs:"originalEstimate,omitempty"`
+	RemainingEstimate        Duration `json:"remainingEstimate,omitempty" structs:"remainingEstimate,omitempty"`
+	TimeSpent                Duration `json:"timeSpent,omitempty" structs:"timeSpent,omitempty"`
+	OriginalEstimateSeconds  int      `json:"originalEstimateSeconds,omitempty" structs:"originalEstimateSeconds,omitempty"`
+	RemainingEstimateSeconds int      `json:"remainingEstimateSeconds,omitempty" structs:"remainingEstimateSeconds,omitempty"`
+	TimeSpentSeconds         int      `json:"timeSpentSeconds,omitempty" structs:"timeSpentSeconds,omitempty"`
 }
 
 // Subtasks represents all issues of a parent issue.
@@ -465,8 +463,12 @@ type IssueLinkType struct {
 }
 
 // Comments represents a list of Comment.
+// StartAt, MaxResults and Total describe the page of comments when the list is paginated.
 type Comments struct {
-	Comments []*Comment `json:"comments,omitempty" structs:"comments,omitempty"`
+	StartAt    int        `json:"startAt,omitempty" structs:"startAt,omitempty"`
+	MaxResults int        `json:"maxResults,omitempty" structs:"maxResults,omitempty"`
+	Total      int        `json:"total,omitempty" structs:"total,omitempty"`
+	Comments   []*Comment `json:"comments,omitempty" structs:"comments,omitempty"`
 }
 
 // Comment represents a comment by a person to an issue in Jira.
@@ -476,6 +478,7 @@ type Comment struct {
 	Name         string            `json:"name,omitempty" structs:"name,omitempty"`
 	Author       User              `json:"author,omitempty" structs:"author,omitempty"`
 	Body         string            `json:"body,omitempty" structs:"body,omitempty"`
+	RenderedBody string            `json:"renderedBody,omitempty" structs:"renderedBody,omitempty"`
 	UpdateAuthor User              `json:"updateAuthor,omitempty" structs:"updateAuthor,omitempty"`
 	Updated      string            `json:"updated,omitempty" structs:"updated,omitempty"`
 	Created      string            `json:"created,omitempty" structs:"created,omitempty"`
@@ -544,6 +547,8 @@ type GetQueryOptions struct {
 	FieldsByKeys  bool   `url:"fieldsByKeys,omitempty"`
 	UpdateHistory bool   `url:"updateHistory,omitempty"`
 	ProjectKeys   string `url:"projectKeys,omitempty"`
+	// IssueTypeIds restricts the create metadata of GetCreateMeta to a comma-separated list of issue type IDs.
+	IssueTypeIds string `url:"issuetypeIds,omitempty"`
 }
 
 // GetWorklogsQueryOptions specifies the optional parameters for the Get Worklogs method
@@ -563,6 +568,15 @@ type AddWorklogQueryOptions struct {
 	OverrideEditableFlag bool   `url:"overrideEditableFlag,omitempty"`
 }
 
+// DeleteWorklogQueryOptions specifies the optional parameters for the Delete Worklog method
+type DeleteWorklogQueryOptions struct {
+	NotifyUsers          bool   `url:"notifyUsers,omitempty"`
+	AdjustEstimate       string `url:"adjustEstimate,omitempty"`
+	NewEstimate          string `url:"newEstimate,omitempty"`
+	IncreaseBy           string `url:"increaseBy,omitempty"`
+	OverrideEditableFlag bool   `url:"overrideEditableFlag,omitempty"`
+}
+
 // CustomFields represents custom fields of Jira
 // This can heavily differ between Jira instances
 type CustomFields map[string]string
@@ -658,42 +672,14 @@ func (s *IssueService) DownloadAttachment(ctx context.Context, attachmentID stri
 	return resp, nil
 }
 
-// PostAttachment uploads r (io.Reader) as an attachment to a given issueID
+// PostAttachment uploads r (io.Reader) as an attachment to a given issueID.
+// The content of r is streamed; use UploadAttachments to upload several files at once or to report progress.
 func (s *IssueService) PostAttachment(ctx context.Context, issueID string, r io.Reader, attachmentName string) (*[]Attachment, *Response, error) {
-	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/attachments", issueID)
-
-	b := new(bytes.Buffer)
-	writer := multipart.NewWriter(b)
-
-	fw, err := writer.CreateFormFile("file", attachmentName)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	if r != nil {
-		// Copy the file
-		if _, err = io.Copy(fw, r); err != nil {
-			return nil, nil, err
-		}
-	}
-	writer.Close()
-
-	req, err := s.client.NewMultiPartRequest(ctx, http.MethodPost, apiEndpoint, b)
+	attachments, resp, err := s.UploadAttachments(ctx, issueID, []AttachmentFile{{Name: attachmentName, Reader: r}}, nil)
 	if err != nil {
-		return nil, nil, err
-	}
-
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-
-	// PostAttachment response returns a JSON array (as multiple attachments can be posted)
-	attachment := new([]Attachment)
-	resp, err := s.client.Do(req, attachment)
-	if err != nil {
-		jerr := NewJiraError(resp, err)
-		return nil, resp, jerr
+		return nil, resp, err
 	}
-
-	return attachment, resp, nil
+	return &attachments, resp, nil
 }
 
 // DeleteAttachment deletes an attachment of a given attachmentID
@@ -898,21 +884,19 @@ func (s *IssueService) UpdateComment(ctx context.Context, issueID string, commen
 // DeleteComment Deletes a comment from an issueID.
 //
 // Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v3/#api-api-3-issue-issueIdOrKey-comment-id-delete
-func (s *IssueService) DeleteComment(ctx context.Context, issueID, commentID string) error {
+// Caller must close resp.Body
+func (s *IssueService) DeleteComment(ctx context.Context, issueID, commentID string) (*Response, error) {
 	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/comment/%s", issueID, commentID)
 	req, err := s.client.NewRequest(ctx, http.MethodDelete, apiEndpoint, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	resp, err := s.client.Do(req, nil)
 	if err != nil {
-		jerr := NewJiraError(resp, err)
-		return jerr
+		err = NewJiraError(resp, err)
 	}
-	defer resp.Body.Close()
-
-	return nil
+	return resp, err
 }
 
 // AddWorklogRecord adds a new worklog record to issueID.
@@ -969,6 +953,32 @@ func (s *IssueService) UpdateWorklogRecord(ctx context.Context, issueID, worklog
 	return responseRecord, resp, nil
 }
 
+// DeleteWorklogRecord deletes a worklog record from issueID.
+// Use WithQueryOptions with DeleteWorklogQueryOptions to control how the remaining estimate is adjusted.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/issue-deleteWorklog
+// Caller must close resp.Body
+func (s *IssueService) DeleteWorklogRecord(ctx context.Context, issueID, worklogID string, options ...func(*http.Request) error) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/worklog/%s", issueID, worklogID)
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, apiEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, option := range options {
+		err = option(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		err = NewJiraError(resp, err)
+	}
+	return resp, err
+}
+
 // AddLink adds a link between two issues.
 //
 // Jira API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issueLink
@@ -1286,12 +1296,14 @@ func (s *IssueService) GetWatchers(ctx context.Context, issueID string) (*[]User
 
 	result := []User{}
 	for _, watcher := range watches.Watchers {
-		var user *User
-		if watcher.AccountID != "" {
-			user, resp, err = s.client.User.GetByAccountID(context.Background(), watcher.AccountID)
-			if err != nil {
-				return nil, resp, NewJiraError(resp, err)
-			}
+		// Jira Server / Data Center identifies watchers by name and has no account IDs
+		if watcher.AccountID == "" {
+			result = append(result, watcher.User())
+			continue
+		}
+		user, resp, err := s.client.User.GetByAccountID(ctx, watcher.AccountID)
+		if err != nil {
+			return nil, resp, NewJiraError(resp, err)
 		}
 		result = append(result, *user)
 	}
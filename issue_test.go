@@ -244,7 +244,23 @@ func TestIssueService_DeleteComment(t *testing.T) {
 		fmt.Fprint(w, `{}`)
 	})
 
-	err := testClient.Issue.DeleteComment(context.Background(), "10000", "10001")
+	_, err := testClient.Issue.DeleteComment(context.Background(), "10000", "10001")
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestIssueService_DeleteWorklogRecord(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/10000/worklog/10001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		testRequestURL(t, r, "/rest/api/2/issue/10000/worklog/10001?adjustEstimate=leave")
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := testClient.Issue.DeleteWorklogRecord(context.Background(), "10000", "10001", WithQueryOptions(&DeleteWorklogQueryOptions{AdjustEstimate: "leave"}))
 	if err != nil {
 		t.Errorf("Error given: %s", err)
 	}
@@ -261,7 +277,7 @@ func TestIssueService_AddWorklogRecord(t *testing.T) {
 		fmt.Fprint(w, `{"self":"http://www.example.com/jira/rest/api/2/issue/10010/worklog/10000","author":{"self":"http://www.example.com/jira/rest/api/2/user?username=fred","name":"fred","displayName":"Fred F. User","active":false},"updateAuthor":{"self":"http://www.example.com/jira/rest/api/2/user?username=fred","name":"fred","displayName":"Fred F. User","active":false},"comment":"I did some work here.","updated":"2018-02-14T22:14:46.003+0000","visibility":{"type":"group","value":"jira-developers"},"started":"2018-02-14T22:14:46.003+0000","timeSpent":"3h 20m","timeSpentSeconds":12000,"id":"100028","issueId":"10002"}`)
 	})
 	r := &WorklogRecord{
-		TimeSpent: "1h",
+		TimeSpent: Duration(time.Hour),
 	}
 	record, _, err := testClient.Issue.AddWorklogRecord(context.Background(), "10000", r)
 	if record == nil {
@@ -283,7 +299,7 @@ func TestIssueService_UpdateWorklogRecord(t *testing.T) {
 		fmt.Fprint(w, `{"self":"http://www.example.com/jira/rest/api/2/issue/10000/worklog/1","author":{"self":"http://www.example.com/jira/rest/api/2/user?username=fred","name":"fred","displayName":"Fred F. User","active":false},"updateAuthor":{"self":"http://www.example.com/jira/rest/api/2/user?username=fred","name":"fred","displayName":"Fred F. User","active":false},"comment":"I did some work here.","updated":"2018-02-14T22:14:46.003+0000","visibility":{"type":"group","value":"jira-developers"},"started":"2018-02-14T22:14:46.003+0000","timeSpent":"3h 20m","timeSpentSeconds":12000,"id":"100028","issueId":"10002"}`)
 	})
 	r := &WorklogRecord{
-		TimeSpent: "1h",
+		TimeSpent: Duration(time.Hour),
 	}
 	record, _, err := testClient.Issue.UpdateWorklogRecord(context.Background(), "10000", "1", r)
 	if record == nil {
@@ -1503,7 +1519,7 @@ func TestIssueService_GetWorklogs(t *testing.T) {
 						Created:          getTime(time.Date(2016, time.March, 16, 4, 22, 37, 356000000, time.UTC)),
 						Started:          getTime(time.Date(2016, time.March, 16, 4, 22, 37, 356000000, time.UTC)),
 						Updated:          getTime(time.Date(2016, time.March, 16, 4, 22, 37, 356000000, time.UTC)),
-						TimeSpent:        "1h",
+						TimeSpent:        Duration(time.Hour),
 						TimeSpentSeconds: 3600,
 						ID:               "3",
 						IssueID:          "10002",
@@ -1536,7 +1552,7 @@ func TestIssueService_GetWorklogs(t *testing.T) {
 						Created:          getTime(time.Date(2016, time.March, 16, 4, 22, 37, 356000000, time.UTC)),
 						Started:          getTime(time.Date(2016, time.March, 16, 4, 22, 37, 356000000, time.UTC)),
 						Updated:          getTime(time.Date(2016, time.March, 16, 4, 22, 37, 356000000, time.UTC)),
-						TimeSpent:        "1h",
+						TimeSpent:        Duration(time.Hour),
 						TimeSpentSeconds: 3600,
 						ID:               "3",
 						IssueID:          "10002",
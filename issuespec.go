@@ -0,0 +1,520 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IssueSpecFile is a declarative description of issues to create with IssueService.CreateFromSpecs.
+// Project and IssueType are the defaults of all issues which do not set their own.
+//
+// Example in YAML:
+//
+//	project: OPS
+//	issueType: Task
+//	issues:
+//	  - id: epic
+//	    issueType: Epic
+//	    summary: "Release {{.Version}}"
+//	    fields:
+//	      Epic Name: "{{.Version}}"
+//	  - id: notes
+//	    summary: Write release notes
+//	    epic: epic
+//	    labels: [release]
+//	    fields:
+//	      customfield_10010: 3
+//	    links:
+//	      - type: Blocks
+//	        outward: OPS-42
+//	    subtasks:
+//	      - summary: Collect changes
+type IssueSpecFile struct {
+	Project   string      `json:"project,omitempty" yaml:"project,omitempty"`
+	IssueType string      `json:"issueType,omitempty" yaml:"issueType,omitempty"`
+	Issues    []IssueSpec `json:"issues" yaml:"issues"`
+}
+
+// IssueSpec describes an issue to create.
+// References to other issues, like Parent, Epic and the links, are either the ID of a spec in the same file
+// or the key of an existing issue.
+type IssueSpec struct {
+	// ID references the issue within the spec file. Issues without an ID cannot be referenced.
+	ID          string   `json:"id,omitempty" yaml:"id,omitempty"`
+	Project     string   `json:"project,omitempty" yaml:"project,omitempty"`
+	IssueType   string   `json:"issueType,omitempty" yaml:"issueType,omitempty"`
+	Summary     string   `json:"summary" yaml:"summary"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Labels      []string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	// Components are component names.
+	Components []string `json:"components,omitempty" yaml:"components,omitempty"`
+	// Parent makes the issue a subtask of the referenced issue.
+	Parent string `json:"parent,omitempty" yaml:"parent,omitempty"`
+	// Epic sets the Epic Link field to the referenced issue.
+	Epic string `json:"epic,omitempty" yaml:"epic,omitempty"`
+	// Fields are further field values by field ID or name. Values are converted according to the field schema,
+	// e.g. an option can be given by its value and a user by name or account ID.
+	Fields map[string]interface{} `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Links  []IssueLinkSpec        `json:"links,omitempty" yaml:"links,omitempty"`
+	// Subtasks are created as subtasks of this issue, by default with the subtask issue type of the project.
+	Subtasks []IssueSpec `json:"subtasks,omitempty" yaml:"subtasks,omitempty"`
+}
+
+// IssueLinkSpec describes a link from the issue of the spec to another issue.
+// Exactly one of Outward and Inward is set, e.g. Outward for "this issue blocks the other issue".
+type IssueLinkSpec struct {
+	// Type is the name of the issue link type, e.g. "Blocks".
+	Type    string `json:"type" yaml:"type"`
+	Outward string `json:"outward,omitempty" yaml:"outward,omitempty"`
+	Inward  string `json:"inward,omitempty" yaml:"inward,omitempty"`
+}
+
+// IssueSpecResult describes the issues created by IssueService.CreateFromSpecs.
+type IssueSpecResult struct {
+	// Keys maps the IDs of the specs to the keys of the created issues.
+	Keys map[string]string
+	// Issues are the created issues in creation order, holding the ID, key and self link returned by Jira.
+	Issues []*Issue
+}
+
+// IssueSpecError lists all problems found while validating issue specs.
+type IssueSpecError struct {
+	Problems []string
+}
+
+func (e *IssueSpecError) Error() string {
+	return "invalid issue specs: " + strings.Join(e.Problems, "; ")
+}
+
+// issueKeyPattern matches the keys of existing issues
+var issueKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*-[0-9]+$`)
+
+// ParseIssueSpecs parses issue specs in YAML or JSON. After decoding, each string value is executed
+// as text/template with data, so placeholders like {{.Version}} can be used and the values of data
+// are never interpreted as YAML. Values starting with a placeholder must be quoted in YAML.
+func ParseIssueSpecs(document []byte, data interface{}) (*IssueSpecFile, error) {
+	file := new(IssueSpecFile)
+	// YAML is a superset of JSON, so a single decoder reads both
+	if err := yaml.Unmarshal(document, file); err != nil {
+		return nil, fmt.Errorf("decoding issue specs: %w", err)
+	}
+
+	t := &specTemplater{data: data}
+	t.text(&file.Project)
+	t.text(&file.IssueType)
+	for i := range file.Issues {
+		t.spec(&file.Issues[i])
+	}
+	if t.err != nil {
+		return nil, t.err
+	}
+	return file, nil
+}
+
+// specTemplater executes the string values of issue specs as text/template and keeps the first error
+type specTemplater struct {
+	data interface{}
+	err  error
+}
+
+func (t *specTemplater) spec(spec *IssueSpec) {
+	for _, s := range []*string{&spec.ID, &spec.Project, &spec.IssueType, &spec.Summary, &spec.Description, &spec.Parent, &spec.Epic} {
+		t.text(s)
+	}
+	for i := range spec.Labels {
+		t.text(&spec.Labels[i])
+	}
+	for i := range spec.Components {
+		t.text(&spec.Components[i])
+	}
+	for name, value := range spec.Fields {
+		spec.Fields[name] = t.value(value)
+	}
+	for i := range spec.Links {
+		t.text(&spec.Links[i].Type)
+		t.text(&spec.Links[i].Outward)
+		t.text(&spec.Links[i].Inward)
+	}
+	for i := range spec.Subtasks {
+		t.spec(&spec.Subtasks[i])
+	}
+}
+
+// value executes the strings within a decoded field value
+func (t *specTemplater) value(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		t.text(&v)
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = t.value(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = t.value(v[k])
+		}
+	}
+	return v
+}
+
+func (t *specTemplater) text(s *string) {
+	if t.err != nil || !strings.Contains(*s, "{{") {
+		return
+	}
+	tmpl, err := template.New("issues").Option("missingkey=error").Parse(*s)
+	if err != nil {
+		t.err = fmt.Errorf("parsing issue spec template: %w", err)
+		return
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, t.data); err != nil {
+		t.err = fmt.Errorf("executing issue spec template: %w", err)
+		return
+	}
+	*s = buf.String()
+}
+
+// plannedIssue is an issue spec with its subtasks flattened and its defaults applied
+type plannedIssue struct {
+	spec   IssueSpec
+	id     string
+	target *createTarget
+}
+
+// ValidateIssueSpecs checks the issue specs against the create metadata of their projects and issue types
+// without creating anything. All problems are returned at once as *IssueSpecError.
+func (s *IssueService) ValidateIssueSpecs(ctx context.Context, file *IssueSpecFile) error {
+	_, err := s.planIssueSpecs(ctx, file)
+	return err
+}
+
+// CreateFromSpecs validates the issue specs and creates the issues, parents and epics before the issues
+// referencing them, and links the issues once all are created. Nothing is created if validation fails.
+// The result holds everything created before an error occurred.
+func (s *IssueService) CreateFromSpecs(ctx context.Context, file *IssueSpecFile) (*IssueSpecResult, error) {
+	planned, err := s.planIssueSpecs(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &IssueSpecResult{Keys: make(map[string]string)}
+	for _, p := range planned {
+		fields, problems := s.specFields(ctx, p, result.Keys)
+		if len(problems) > 0 {
+			return result, &IssueSpecError{Problems: problems}
+		}
+		issue, _, err := s.Create(ctx, &Issue{Fields: &IssueFields{Unknowns: fields}})
+		if err != nil {
+			return result, fmt.Errorf("creating issue %s: %w", p.id, err)
+		}
+		result.Keys[p.id] = issue.Key
+		result.Issues = append(result.Issues, issue)
+	}
+
+	for _, p := range planned {
+		for _, l := range p.spec.Links {
+			link := &IssueLink{Type: IssueLinkType{Name: l.Type}}
+			if l.Outward != "" {
+				link.InwardIssue = &Issue{Key: result.Keys[p.id]}
+				link.OutwardIssue = &Issue{Key: resolveSpecRef(l.Outward, result.Keys)}
+			} else {
+				link.InwardIssue = &Issue{Key: resolveSpecRef(l.Inward, result.Keys)}
+				link.OutwardIssue = &Issue{Key: result.Keys[p.id]}
+			}
+			resp, err := s.AddLink(ctx, link)
+			if err != nil {
+				return result, fmt.Errorf("linking issue %s: %w", p.id, err)
+			}
+			resp.Body.Close()
+		}
+	}
+	return result, nil
+}
+
+// planIssueSpecs flattens and validates the specs and returns them in creation order
+func (s *IssueService) planIssueSpecs(ctx context.Context, file *IssueSpecFile) ([]*plannedIssue, error) {
+	var problems []string
+	var planned []*plannedIssue
+	ids := make(map[string]bool)
+
+	var flatten func(specs []IssueSpec, parentID, path, project, issueType string)
+	flatten = func(specs []IssueSpec, parentID, path, project, issueType string) {
+		for i, spec := range specs {
+			p := &plannedIssue{spec: spec, id: spec.ID}
+			if p.id == "" {
+				p.id = fmt.Sprintf("%s[%d]", path, i)
+			} else if ids[p.id] {
+				problems = append(problems, fmt.Sprintf("%s: duplicate ID", p.id))
+			}
+			ids[p.id] = true
+
+			if p.spec.Project == "" {
+				p.spec.Project = project
+			}
+			if p.spec.IssueType == "" {
+				p.spec.IssueType = issueType
+			}
+			if parentID != "" {
+				if p.spec.Parent != "" && p.spec.Parent != parentID {
+					problems = append(problems, fmt.Sprintf("%s: subtask has parent %q", p.id, p.spec.Parent))
+				}
+				p.spec.Parent = parentID
+			}
+			p.spec.Subtasks = nil
+			planned = append(planned, p)
+
+			// Subtasks without an issue type get the subtask type of the project below
+			flatten(spec.Subtasks, p.id, p.id+".subtasks", p.spec.Project, "")
+		}
+	}
+	flatten(file.Issues, "", "issues", file.Project, file.IssueType)
+
+	// Validate the fields with placeholder keys for the issues which are not created yet
+	placeholders := make(map[string]string, len(ids))
+	for id := range ids {
+		placeholders[id] = "PENDING-1"
+	}
+	targets := newCreateTargets(s)
+	for _, p := range planned {
+		if p.spec.Project != "" && p.spec.IssueType == "" && p.spec.Parent != "" {
+			issueType, err := targets.subtaskType(ctx, p.spec.Project)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %s", p.id, err))
+				continue
+			}
+			p.spec.IssueType = issueType
+		}
+		if p.spec.Project == "" || p.spec.IssueType == "" {
+			problems = append(problems, fmt.Sprintf("%s: project and issue type are required", p.id))
+			continue
+		}
+		if strings.TrimSpace(p.spec.Summary) == "" {
+			problems = append(problems, fmt.Sprintf("%s: summary is required", p.id))
+		}
+		target, err := targets.get(ctx, p.spec.Project, p.spec.IssueType)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", p.id, err))
+			continue
+		}
+		p.target = target
+
+		refs := []string{p.spec.Parent, p.spec.Epic}
+		for _, l := range p.spec.Links {
+			if (l.Outward == "") == (l.Inward == "") {
+				problems = append(problems, fmt.Sprintf("%s: link %q needs either an outward or an inward issue", p.id, l.Type))
+			}
+			refs = append(refs, l.Outward, l.Inward)
+		}
+		for _, ref := range refs {
+			if ref != "" && !ids[ref] && !issueKeyPattern.MatchString(ref) {
+				problems = append(problems, fmt.Sprintf("%s: unknown issue %q", p.id, ref))
+			}
+		}
+
+		fields, fieldProblems := s.specFields(ctx, p, placeholders)
+		problems = append(problems, fieldProblems...)
+		violations, err := validateSpecFields(target, fields)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range violations {
+			problems = append(problems, fmt.Sprintf("%s: %s", p.id, v))
+		}
+	}
+
+	ordered, err := orderIssueSpecs(planned)
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	if len(problems) > 0 {
+		return nil, &IssueSpecError{Problems: problems}
+	}
+	return ordered, nil
+}
+
+// orderIssueSpecs sorts the issues so that parents and epics are created before the issues referencing them
+func orderIssueSpecs(planned []*plannedIssue) ([]*plannedIssue, error) {
+	byID := make(map[string]*plannedIssue, len(planned))
+	for _, p := range planned {
+		byID[p.id] = p
+	}
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(planned))
+	ordered := make([]*plannedIssue, 0, len(planned))
+	var visit func(p *plannedIssue) error
+	visit = func(p *plannedIssue) error {
+		switch state[p.id] {
+		case visiting:
+			return fmt.Errorf("%s: circular parent or epic reference", p.id)
+		case done:
+			return nil
+		}
+		state[p.id] = visiting
+		for _, ref := range []string{p.spec.Parent, p.spec.Epic} {
+			if dep, ok := byID[ref]; ok {
+				if err := visit(dep); err != nil {
+					return err
+				}
+			}
+		}
+		state[p.id] = done
+		ordered = append(ordered, p)
+		return nil
+	}
+	for _, p := range planned {
+		if err := visit(p); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// validateSpecFields checks the fields built from a spec against the create metadata
+func validateSpecFields(target *createTarget, fields map[string]interface{}) ([]FieldViolation, error) {
+	// Round trip through JSON, so the values have the types validateFieldValues expects
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, err
+	}
+	return validateFieldValues(target.fields, values), nil
+}
+
+// resolveSpecRef returns the key of the referenced issue
+func resolveSpecRef(ref string, keys map[string]string) string {
+	if key, ok := keys[ref]; ok {
+		return key
+	}
+	return ref
+}
+
+// specFields builds the fields to create the planned issue with, resolving references with keys
+func (s *IssueService) specFields(ctx context.Context, p *plannedIssue, keys map[string]string) (map[string]interface{}, []string) {
+	t := p.target
+	var problems []string
+	fields := map[string]interface{}{
+		"project":   t.projectRef(),
+		"issuetype": map[string]interface{}{"id": t.issueTypeID},
+		"summary":   p.spec.Summary,
+	}
+	if p.spec.Description != "" {
+		fields["description"] = p.spec.Description
+	}
+	if len(p.spec.Labels) > 0 {
+		fields["labels"] = p.spec.Labels
+	}
+	if len(p.spec.Components) > 0 {
+		components := make([]map[string]interface{}, len(p.spec.Components))
+		for i, name := range p.spec.Components {
+			components[i] = map[string]interface{}{"name": name}
+		}
+		fields["components"] = components
+	}
+	if p.spec.Parent != "" {
+		if !t.subtask {
+			problems = append(problems, fmt.Sprintf("%s: issue type %s is no subtask type", p.id, p.spec.IssueType))
+		}
+		fields["parent"] = map[string]interface{}{"key": resolveSpecRef(p.spec.Parent, keys)}
+	}
+	if p.spec.Epic != "" {
+		epicLink := ""
+		for id, f := range t.fields {
+			if f.Schema.Custom == epicLinkCustomType {
+				epicLink = id
+			}
+		}
+		if epicLink == "" {
+			problems = append(problems, fmt.Sprintf("%s: no Epic Link field on the create screen of %s in %s", p.id, p.spec.IssueType, t.projectKey))
+		} else {
+			fields[epicLink] = resolveSpecRef(p.spec.Epic, keys)
+		}
+	}
+
+	names := make([]string, 0, len(p.spec.Fields))
+	for name := range p.spec.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		field, ok := t.field(name)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: field %q is not on the create screen of %s in %s", p.id, name, p.spec.IssueType, t.projectKey))
+			continue
+		}
+		value, err := s.specFieldValue(ctx, &field, p.spec.Fields[name])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: field %q: %s", p.id, name, err))
+			continue
+		}
+		fields[field.FieldID] = value
+	}
+
+	sort.Strings(problems)
+	return fields, problems
+}
+
+// specFieldValue converts a value given in a spec to the form Jira expects for the field
+func (s *IssueService) specFieldValue(ctx context.Context, field *ProjectIssueField, value interface{}) (interface{}, error) {
+	if field.Schema.Type == "array" {
+		items, ok := value.([]interface{})
+		if !ok {
+			items = []interface{}{value}
+		}
+		converted := make([]interface{}, len(items))
+		for i, item := range items {
+			v, err := s.specItemValue(ctx, field.Schema.Items, item)
+			if err != nil {
+				return nil, err
+			}
+			converted[i] = v
+		}
+		return converted, nil
+	}
+	return s.specItemValue(ctx, field.Schema.Type, value)
+}
+
+// specItemValue converts a single value of the schema type; objects are passed on unchanged
+func (s *IssueService) specItemValue(ctx context.Context, schemaType string, value interface{}) (interface{}, error) {
+	str, isString := value.(string)
+	if !isString {
+		if schemaType == "string" {
+			return nil, fmt.Errorf("expected a string, got %v", value)
+		}
+		return value, nil
+	}
+
+	switch schemaType {
+	case "option":
+		return map[string]interface{}{"value": str}, nil
+	case "component", "version", "priority", "resolution", "issuetype", "group":
+		return map[string]interface{}{"name": str}, nil
+	case "project":
+		return map[string]interface{}{"key": str}, nil
+	case "user":
+		cloud, err := s.client.Configuration.IsCloud(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if cloud {
+			return map[string]interface{}{"accountId": str}, nil
+		}
+		return map[string]interface{}{"name": str}, nil
+	}
+	return str, nil
+}
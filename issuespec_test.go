@@ -0,0 +1,197 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const issueSpecTestCreateMeta = `{"projects":[{"id":"10000","key":"OPS","issuetypes":[
+	{"id":"1","name":"Epic","fields":{
+		"summary":{"required":true,"name":"Summary","schema":{"type":"string","system":"summary"}},
+		"customfield_10011":{"required":true,"name":"Epic Name","schema":{"type":"string","custom":"com.pyxis.greenhopper.jira:gh-epic-label"}}
+	}},
+	{"id":"3","name":"Task","fields":{
+		"summary":{"required":true,"name":"Summary","schema":{"type":"string","system":"summary"}},
+		"labels":{"required":false,"name":"Labels","schema":{"type":"array","items":"string","system":"labels"}},
+		"customfield_10014":{"required":false,"name":"Epic Link","schema":{"type":"any","custom":"com.pyxis.greenhopper.jira:gh-epic-link"}},
+		"customfield_10020":{"required":false,"name":"Team","schema":{"type":"option"}},
+		"customfield_10030":{"required":false,"name":"Points","schema":{"type":"number"}}
+	}},
+	{"id":"5","name":"Subtask","subtask":true,"fields":{
+		"summary":{"required":true,"name":"Summary","schema":{"type":"string","system":"summary"}}
+	}}]}]}`
+
+const issueSpecTestDocument = `
+project: OPS
+issueType: Task
+issues:
+  - id: notes
+    summary: Write release notes for {{.Version}}
+    epic: epic
+    labels: [release]
+    fields:
+      Team: Blue
+      customfield_10030: 3
+    links:
+      - type: Blocks
+        outward: OPS-42
+    subtasks:
+      - summary: Collect changes
+  - id: epic
+    issueType: Epic
+    summary: Release {{.Version}}
+    fields:
+      Epic Name: "{{.Version}}"
+`
+
+func TestParseIssueSpecs(t *testing.T) {
+	file, err := ParseIssueSpecs([]byte(issueSpecTestDocument), map[string]string{"Version": "1.2"})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if file.Project != "OPS" || len(file.Issues) != 2 {
+		t.Fatalf("Unexpected spec file %+v", file)
+	}
+	notes := file.Issues[0]
+	if notes.Summary != "Write release notes for 1.2" || notes.Fields["customfield_10030"] != 3 || len(notes.Subtasks) != 1 {
+		t.Errorf("Unexpected spec %+v", notes)
+	}
+	if file.Issues[1].Fields["Epic Name"] != "1.2" {
+		t.Errorf("Unexpected spec %+v", file.Issues[1])
+	}
+
+	file, err = ParseIssueSpecs([]byte(`{"project":"OPS","issues":[{"summary":"From JSON","labels":["a"]}]}`), nil)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if file.Issues[0].Summary != "From JSON" || file.Issues[0].Labels[0] != "a" {
+		t.Errorf("Unexpected spec file %+v", file)
+	}
+
+	file, err = ParseIssueSpecs([]byte(issueSpecTestDocument), map[string]string{"Version": "1.2: fixes # \"quoted\"\nissueType: Bug"})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if got := file.Issues[1].Summary; got != "Release 1.2: fixes # \"quoted\"\nissueType: Bug" || file.Issues[1].IssueType != "Epic" {
+		t.Errorf("Expected the value to be kept as is, got %q", got)
+	}
+
+	if _, err := ParseIssueSpecs([]byte(`issues: [{summary: "{{.Missing}}"}]`), map[string]string{}); err == nil {
+		t.Error("Expected an error for a missing template key")
+	}
+}
+
+func TestIssueService_ValidateIssueSpecs(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/createmeta", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, issueSpecTestCreateMeta)
+	})
+
+	file := &IssueSpecFile{Project: "OPS", IssueType: "Task", Issues: []IssueSpec{
+		{ID: "a", Summary: "A", Fields: map[string]interface{}{"Unknown": 1}},
+		{ID: "a", Summary: "", Parent: "nowhere"},
+		{ID: "b", IssueType: "Epic", Summary: "B"},
+		{ID: "c", Summary: "C", Links: []IssueLinkSpec{{Type: "Blocks"}}},
+	}}
+	err := testClient.Issue.ValidateIssueSpecs(context.Background(), file)
+	var specErr *IssueSpecError
+	if !errors.As(err, &specErr) {
+		t.Fatalf("Expected IssueSpecError, got %v", err)
+	}
+
+	want := []string{
+		`a: duplicate ID`,
+		`a: field "Unknown" is not on the create screen of Task in OPS`,
+		`a: summary is required`,
+		`a: unknown issue "nowhere"`,
+		`a: issue type Task is no subtask type`,
+		`b: field "Epic Name" (customfield_10011) is required`,
+		`c: link "Blocks" needs either an outward or an inward issue`,
+	}
+	for _, w := range want {
+		found := false
+		for _, p := range specErr.Problems {
+			if p == w {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected problem %q in %q", w, specErr.Problems)
+		}
+	}
+}
+
+func TestIssueService_CreateFromSpecs(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/createmeta", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, issueSpecTestCreateMeta)
+	})
+
+	var created []map[string]interface{}
+	testMux.HandleFunc("/rest/api/2/issue", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		var body struct {
+			Fields map[string]interface{} `json:"fields"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		created = append(created, body.Fields)
+		fmt.Fprintf(w, `{"id":"%d","key":"OPS-%d"}`, 100+len(created), 100+len(created))
+	})
+	var links []string
+	testMux.HandleFunc("/rest/api/2/issueLink", func(w http.ResponseWriter, r *http.Request) {
+		var link IssueLink
+		if err := json.NewDecoder(r.Body).Decode(&link); err != nil {
+			t.Fatal(err)
+		}
+		links = append(links, fmt.Sprintf("%s %s->%s", link.Type.Name, link.InwardIssue.Key, link.OutwardIssue.Key))
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	file, err := ParseIssueSpecs([]byte(issueSpecTestDocument), map[string]string{"Version": "1.2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := testClient.Issue.CreateFromSpecs(context.Background(), file)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	wantKeys := map[string]string{"epic": "OPS-101", "notes": "OPS-102", "notes.subtasks[0]": "OPS-103"}
+	if !reflect.DeepEqual(result.Keys, wantKeys) {
+		t.Errorf("Expected keys %v, got %v", wantKeys, result.Keys)
+	}
+	if len(created) != 3 {
+		t.Fatalf("Expected 3 created issues, got %d", len(created))
+	}
+	wantNotes := map[string]interface{}{
+		"project":           map[string]interface{}{"id": "10000"},
+		"issuetype":         map[string]interface{}{"id": "3"},
+		"summary":           "Write release notes for 1.2",
+		"labels":            []interface{}{"release"},
+		"customfield_10014": "OPS-101",
+		"customfield_10020": map[string]interface{}{"value": "Blue"},
+		"customfield_10030": float64(3),
+	}
+	if !reflect.DeepEqual(created[1], wantNotes) {
+		t.Errorf("Unexpected fields:\n got %v\nwant %v", created[1], wantNotes)
+	}
+	if parent, _ := created[2]["parent"].(map[string]interface{}); parent["key"] != "OPS-102" {
+		t.Errorf("Expected subtask of OPS-102, got %v", created[2])
+	}
+	if issueType, _ := created[2]["issuetype"].(map[string]interface{}); issueType["id"] != "5" {
+		t.Errorf("Expected the subtask issue type of the project, got %v", created[2])
+	}
+	if strings.Join(links, ",") != "Blocks OPS-102->OPS-42" {
+		t.Errorf("Unexpected links %v", links)
+	}
+}
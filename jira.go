@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -38,6 +39,19 @@ type Client struct {
 	// TODO Needed in Cloud and/or onpremise?
 	session *Session
 
+	// deploymentType caches the deployment type of the instance, see ConfigurationService.IsCloud.
+	deploymentMu   sync.Mutex
+	deploymentType string
+
+	// createMeta caches create metadata, see IssueService.GetCreateMetaIssueType.
+	createMetaMu    sync.Mutex
+	createMetaTTL   time.Duration
+	createMetaCache map[string]createMetaEntry
+
+	// cache caches responses of GET requests if enabled, see Client.EnableCache.
+	cacheMu sync.Mutex
+	cache   *responseCache
+
 	// Reuse a single struct instead of allocating one for each service on the heap.
 	common service
 
@@ -64,6 +78,8 @@ type Client struct {
 	ServiceDesk      *ServiceDeskService
 	Customer         *CustomerService
 	Request          *RequestService
+	Configuration    *ConfigurationService
+	Property         *PropertyService
 }
 
 // service is the base structure to bundle API services
@@ -102,6 +118,8 @@ func NewClient(baseURL string, httpClient *http.Client) (*Client, error) {
 		client:    httpClient,
 		BaseURL:   baseEndpoint,
 		UserAgent: defaultUserAgent,
+
+		createMetaTTL: DefaultCreateMetaCacheTTL,
 	}
 	c.common.client = c
 
@@ -128,6 +146,8 @@ func NewClient(baseURL string, httpClient *http.Client) (*Client, error) {
 	c.ServiceDesk = (*ServiceDeskService)(&c.common)
 	c.Customer = (*CustomerService)(&c.common)
 	c.Request = (*RequestService)(&c.common)
+	c.Configuration = (*ConfigurationService)(&c.common)
+	c.Property = (*PropertyService)(&c.common)
 
 	return c, nil
 }
@@ -284,7 +304,7 @@ func (c *Client) NewMultiPartRequest(ctx context.Context, method, urlStr string,
 // Do sends an API request and returns the API response.
 // The API response is JSON decoded and stored in the value pointed to by v, or returned as an error if an API error has occurred.
 func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
-	httpResp, err := c.client.Do(req)
+	httpResp, fromCache, err := c.doCached(req)
 	if err != nil {
 		return nil, err
 	}
@@ -295,6 +315,7 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 		// in case the caller wants to inspect it further
 		return newResponse(httpResp, nil), err
 	}
+	c.invalidateAfterWrite(req)
 
 	if v != nil {
 		// Open a NewDecoder and defer closing the reader only if there is a provided interface to decode to
@@ -303,6 +324,7 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 	}
 
 	resp := newResponse(httpResp, v)
+	resp.FromCache = fromCache
 	return resp, err
 }
 
@@ -327,6 +349,9 @@ type Response struct {
 	StartAt    int
 	MaxResults int
 	Total      int
+
+	// FromCache is true if the response was served from the response cache, see Client.EnableCache.
+	FromCache bool
 }
 
 func newResponse(r *http.Response, v interface{}) *Response {
@@ -347,5 +372,9 @@ func (r *Response) populatePageValues(v interface{}) {
 		r.StartAt = value.StartAt
 		r.MaxResults = value.MaxResults
 		r.Total = value.Total
+	case *ProjectSearchResult:
+		r.StartAt = value.StartAt
+		r.MaxResults = value.MaxResults
+		r.Total = value.Total
 	}
 }
@@ -0,0 +1,456 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Link directions which can be followed by IssueService.CrawlLinks
+const (
+	LinkDirectionBoth    = ""
+	LinkDirectionOutward = "outward"
+	LinkDirectionInward  = "inward"
+)
+
+// LinkGraphOptions specifies the parameters to IssueService.CrawlLinks
+type LinkGraphOptions struct {
+	// JQL selects the issues the crawl starts from.
+	JQL string
+	// Keys are issues the crawl starts from, in addition to the issues matching JQL.
+	Keys []string
+	// LinkTypes are the names of the link types to follow, e.g. "Blocks". Default: all link types.
+	LinkTypes []string
+	// Direction restricts the links that are followed to LinkDirectionOutward or LinkDirectionInward.
+	// Default: LinkDirectionBoth.
+	Direction string
+	// MaxDepth is the maximum number of links between a start issue and any other issue. Default: unlimited.
+	MaxDepth int
+	// Concurrency is the maximum number of concurrent requests. Default: 4.
+	Concurrency int
+}
+
+func (o *LinkGraphOptions) follows(linkType IssueLinkType) bool {
+	if len(o.LinkTypes) == 0 {
+		return true
+	}
+	for _, t := range o.LinkTypes {
+		if strings.EqualFold(t, linkType.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// LinkEdge is a directed link between two issues, pointing in the outward direction of its link type.
+// For the link type "Blocks", From blocks To.
+type LinkEdge struct {
+	From string
+	To   string
+	// Type is the name of the link type, e.g. "Blocks".
+	Type string
+	// Label is the outward description of the link type, e.g. "blocks".
+	Label string
+}
+
+// LinkGraph is an in-memory graph of issues and the links between them.
+type LinkGraph struct {
+	// Issues are the issues of the graph by key.
+	// Issues beyond the maximum depth only hold the fields included in the issue links.
+	Issues map[string]*Issue
+	Edges  []LinkEdge
+
+	edgeSet map[LinkEdge]bool
+}
+
+// NewLinkGraph returns an empty graph.
+func NewLinkGraph() *LinkGraph {
+	return &LinkGraph{
+		Issues:  make(map[string]*Issue),
+		edgeSet: make(map[LinkEdge]bool),
+	}
+}
+
+// AddIssue adds an issue to the graph, replacing a previously added issue with the same key.
+func (g *LinkGraph) AddIssue(issue *Issue) {
+	g.Issues[issue.Key] = issue
+}
+
+// AddEdge adds an edge to the graph. Duplicate edges are ignored.
+func (g *LinkGraph) AddEdge(edge LinkEdge) {
+	if g.edgeSet == nil {
+		g.edgeSet = make(map[LinkEdge]bool)
+	}
+	if g.edgeSet[edge] {
+		return
+	}
+	g.edgeSet[edge] = true
+	g.Edges = append(g.Edges, edge)
+	for _, key := range []string{edge.From, edge.To} {
+		if _, ok := g.Issues[key]; !ok {
+			g.Issues[key] = &Issue{Key: key}
+		}
+	}
+}
+
+// keys returns the keys of all issues of the graph in sorted order
+func (g *LinkGraph) keys() []string {
+	keys := make([]string, 0, len(g.Issues))
+	for key := range g.Issues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// successors returns the targets of all edges starting at each issue, sorted by key
+func (g *LinkGraph) successors() map[string][]string {
+	next := make(map[string][]string)
+	for _, e := range g.Edges {
+		next[e.From] = append(next[e.From], e.To)
+	}
+	for _, targets := range next {
+		sort.Strings(targets)
+	}
+	return next
+}
+
+// Successors returns the keys of the issues the issue links to in the outward direction,
+// e.g. the issues blocked by it.
+func (g *LinkGraph) Successors(key string) []string {
+	return g.successors()[key]
+}
+
+// Predecessors returns the keys of the issues linking to the issue in the outward direction,
+// e.g. the issues blocking it.
+func (g *LinkGraph) Predecessors(key string) []string {
+	var prev []string
+	for _, e := range g.Edges {
+		if e.To == key {
+			prev = append(prev, e.From)
+		}
+	}
+	sort.Strings(prev)
+	return prev
+}
+
+// Cycles returns the groups of issues which depend on each other in a cycle.
+// Every group is a strongly connected component of more than one issue, or a single issue linking to itself.
+func (g *LinkGraph) Cycles() [][]string {
+	next := g.successors()
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+	counter := 0
+
+	var connect func(key string)
+	connect = func(key string) {
+		index[key] = counter
+		lowlink[key] = counter
+		counter++
+		stack = append(stack, key)
+		onStack[key] = true
+
+		for _, to := range next[key] {
+			if _, seen := index[to]; !seen {
+				connect(to)
+				if lowlink[to] < lowlink[key] {
+					lowlink[key] = lowlink[to]
+				}
+			} else if onStack[to] && index[to] < lowlink[key] {
+				lowlink[key] = index[to]
+			}
+		}
+
+		if lowlink[key] != index[key] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == key {
+				break
+			}
+		}
+		if len(component) > 1 || hasSelfLoop(next, key) {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, key := range g.keys() {
+		if _, seen := index[key]; !seen {
+			connect(key)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+func hasSelfLoop(next map[string][]string, key string) bool {
+	for _, to := range next[key] {
+		if to == key {
+			return true
+		}
+	}
+	return false
+}
+
+// TopologicalOrder returns the keys of all issues so that every issue comes after the issues linking to it,
+// e.g. blockers before the issues they block. Issues without order between them are sorted by key.
+// An error is returned if the graph contains cycles.
+func (g *LinkGraph) TopologicalOrder() ([]string, error) {
+	next := g.successors()
+	inDegree := make(map[string]int, len(g.Issues))
+	for _, e := range g.Edges {
+		inDegree[e.To]++
+	}
+
+	var ready []string
+	for _, key := range g.keys() {
+		if inDegree[key] == 0 {
+			ready = append(ready, key)
+		}
+	}
+
+	order := make([]string, 0, len(g.Issues))
+	for len(ready) > 0 {
+		key := ready[0]
+		ready = ready[1:]
+		order = append(order, key)
+		for _, to := range next[key] {
+			inDegree[to]--
+			if inDegree[to] == 0 {
+				ready = append(ready, to)
+				sort.Strings(ready)
+			}
+		}
+	}
+
+	if len(order) != len(g.Issues) {
+		return nil, fmt.Errorf("link graph contains cycles: %v", g.Cycles())
+	}
+	return order, nil
+}
+
+// CriticalPath returns the longest chain of linked issues, e.g. the longest chain of blockers.
+// The length of a chain is the sum of the weights of its issues. If weight is nil, every issue weighs 1.
+// An error is returned if the graph contains cycles.
+func (g *LinkGraph) CriticalPath(weight func(*Issue) float64) ([]string, float64, error) {
+	if weight == nil {
+		weight = func(*Issue) float64 { return 1 }
+	}
+	order, err := g.TopologicalOrder()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	next := g.successors()
+	length := make(map[string]float64, len(order))
+	prev := make(map[string]string, len(order))
+	for _, key := range order {
+		length[key] += weight(g.Issues[key])
+	}
+	for _, key := range order {
+		for _, to := range next[key] {
+			if l := length[key] + weight(g.Issues[to]); l > length[to] {
+				length[to] = l
+				prev[to] = key
+			}
+		}
+	}
+
+	var end string
+	for _, key := range order {
+		if end == "" || length[key] > length[end] {
+			end = key
+		}
+	}
+	if end == "" {
+		return nil, 0, nil
+	}
+
+	path := []string{end}
+	for key, ok := prev[end]; ok; key, ok = prev[key] {
+		path = append([]string{key}, path...)
+	}
+	return path, length[end], nil
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language.
+func (g *LinkGraph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph links {\n")
+	for _, key := range g.keys() {
+		fmt.Fprintf(&b, "  %q [label=%q];\n", key, issueLabel(g.Issues[key]))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", e.From, e.To, e.Label)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid writes the graph as a Mermaid flowchart.
+func (g *LinkGraph) WriteMermaid(w io.Writer) error {
+	ids := make(map[string]string, len(g.Issues))
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for i, key := range g.keys() {
+		ids[key] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[key], mermaidEscape(issueLabel(g.Issues[key])))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  %s -- \"%s\" --> %s\n", ids[e.From], mermaidEscape(e.Label), ids[e.To])
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func issueLabel(issue *Issue) string {
+	if issue.Fields == nil || issue.Fields.Summary == "" {
+		return issue.Key
+	}
+	return issue.Key + ": " + issue.Fields.Summary
+}
+
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
+
+// addLinks adds the edges of all followed links of the issue to the graph and returns the keys of the linked issues
+func (g *LinkGraph) addLinks(issue *Issue, opts *LinkGraphOptions) []string {
+	if issue.Fields == nil {
+		return nil
+	}
+	var linked []string
+	for _, link := range issue.Fields.IssueLinks {
+		if link == nil || !opts.follows(link.Type) {
+			continue
+		}
+		if link.OutwardIssue != nil && opts.Direction != LinkDirectionInward {
+			g.addLinkedIssue(link.OutwardIssue)
+			g.AddEdge(LinkEdge{From: issue.Key, To: link.OutwardIssue.Key, Type: link.Type.Name, Label: link.Type.Outward})
+			linked = append(linked, link.OutwardIssue.Key)
+		}
+		if link.InwardIssue != nil && opts.Direction != LinkDirectionOutward {
+			g.addLinkedIssue(link.InwardIssue)
+			g.AddEdge(LinkEdge{From: link.InwardIssue.Key, To: issue.Key, Type: link.Type.Name, Label: link.Type.Outward})
+			linked = append(linked, link.InwardIssue.Key)
+		}
+	}
+	return linked
+}
+
+// addLinkedIssue adds the partial issue of a link unless the issue is already known
+func (g *LinkGraph) addLinkedIssue(issue *Issue) {
+	if _, ok := g.Issues[issue.Key]; !ok {
+		g.Issues[issue.Key] = issue
+	}
+}
+
+// maxKeysPerSearch is the number of issues fetched at once with a "key in (...)" query
+const maxKeysPerSearch = 50
+
+// CrawlLinks builds the graph of the issues reachable from the issues selected by options.JQL and options.Keys
+// by following issue links of options.LinkTypes, up to options.MaxDepth links away.
+func (s *IssueService) CrawlLinks(ctx context.Context, options *LinkGraphOptions) (*LinkGraph, error) {
+	opts := LinkGraphOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+	fields := []string{"summary", "status", "issuetype", "issuelinks"}
+
+	graph := NewLinkGraph()
+	fetched := make(map[string]bool)
+
+	level := append([]string{}, opts.Keys...)
+	if opts.JQL != "" {
+		err := s.SearchPages(ctx, opts.JQL, &SearchOptions{MaxResults: 50, Fields: []string{"key"}}, func(issue Issue) error {
+			level = append(level, issue.Key)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for depth := 0; len(level) > 0; depth++ {
+		var keys []string
+		for _, key := range level {
+			if !fetched[key] {
+				fetched[key] = true
+				keys = append(keys, key)
+			}
+		}
+
+		issues, err := s.searchKeys(ctx, keys, fields, opts.Concurrency)
+		if err != nil {
+			return nil, err
+		}
+
+		level = nil
+		for i := range issues {
+			graph.AddIssue(&issues[i])
+			linked := graph.addLinks(&issues[i], &opts)
+			if opts.MaxDepth <= 0 || depth < opts.MaxDepth {
+				level = append(level, linked...)
+			}
+		}
+	}
+
+	return graph, nil
+}
+
+// searchKeys fetches the issues with the given keys in batches, running at most concurrency searches at once
+func (s *IssueService) searchKeys(ctx context.Context, keys []string, fields []string, concurrency int) ([]Issue, error) {
+	var batches [][]string
+	for start := 0; start < len(keys); start += maxKeysPerSearch {
+		end := start + maxKeysPerSearch
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batches = append(batches, keys[start:end])
+	}
+
+	results := make([][]Issue, len(batches))
+	errs := make([]error, len(batches))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		wg.Add(1)
+		go func(i int, batch []string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			jql := fmt.Sprintf("key in (%s)", strings.Join(batch, ","))
+			errs[i] = s.SearchPages(ctx, jql, &SearchOptions{MaxResults: maxKeysPerSearch, Fields: fields}, func(issue Issue) error {
+				results[i] = append(results[i], issue)
+				return nil
+			})
+		}(i, batch)
+	}
+	wg.Wait()
+
+	var issues []Issue
+	for i := range batches {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		issues = append(issues, results[i]...)
+	}
+	return issues, nil
+}
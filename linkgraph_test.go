@@ -0,0 +1,142 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testLinkGraph(edges ...[2]string) *LinkGraph {
+	g := NewLinkGraph()
+	for _, e := range edges {
+		g.AddEdge(LinkEdge{From: e[0], To: e[1], Type: "Blocks", Label: "blocks"})
+	}
+	return g
+}
+
+func TestLinkGraph_TopologicalOrder(t *testing.T) {
+	g := testLinkGraph([2]string{"A-1", "A-3"}, [2]string{"A-2", "A-3"}, [2]string{"A-3", "A-4"}, [2]string{"A-1", "A-3"})
+
+	if len(g.Edges) != 3 {
+		t.Errorf("Expected duplicate edges to be ignored, got %v", g.Edges)
+	}
+	order, err := g.TopologicalOrder()
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if want := []string{"A-1", "A-2", "A-3", "A-4"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("Expected order %v, got %v", want, order)
+	}
+	if want := []string{"A-1", "A-2"}; !reflect.DeepEqual(g.Predecessors("A-3"), want) {
+		t.Errorf("Expected predecessors %v, got %v", want, g.Predecessors("A-3"))
+	}
+}
+
+func TestLinkGraph_Cycles(t *testing.T) {
+	g := testLinkGraph([2]string{"A-1", "A-2"}, [2]string{"A-2", "A-3"}, [2]string{"A-3", "A-1"}, [2]string{"A-3", "A-4"}, [2]string{"A-5", "A-5"})
+
+	want := [][]string{{"A-1", "A-2", "A-3"}, {"A-5"}}
+	if got := g.Cycles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected cycles %v, got %v", want, got)
+	}
+	if _, err := g.TopologicalOrder(); err == nil {
+		t.Error("Expected an error for a graph with cycles")
+	}
+	if _, _, err := g.CriticalPath(nil); err == nil {
+		t.Error("Expected an error for a graph with cycles")
+	}
+}
+
+func TestLinkGraph_CriticalPath(t *testing.T) {
+	g := testLinkGraph([2]string{"A-1", "A-2"}, [2]string{"A-2", "A-4"}, [2]string{"A-1", "A-3"}, [2]string{"A-3", "A-4"})
+	g.Issues["A-3"].Fields = &IssueFields{TimeEstimate: 5}
+
+	path, length, err := g.CriticalPath(nil)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if length != 3 || len(path) != 3 {
+		t.Errorf("Expected a path of 3 issues, got %v (%v)", path, length)
+	}
+
+	path, length, err = g.CriticalPath(func(issue *Issue) float64 {
+		if issue.Fields == nil {
+			return 1
+		}
+		return float64(issue.Fields.TimeEstimate)
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if want := []string{"A-1", "A-3", "A-4"}; !reflect.DeepEqual(path, want) || length != 7 {
+		t.Errorf("Expected path %v with length 7, got %v (%v)", want, path, length)
+	}
+}
+
+func TestLinkGraph_Export(t *testing.T) {
+	g := testLinkGraph([2]string{"A-1", "A-2"})
+	g.Issues["A-1"].Fields = &IssueFields{Summary: `Say "hi"`}
+
+	var dot bytes.Buffer
+	if err := g.WriteDOT(&dot); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if !strings.Contains(dot.String(), `"A-1" -> "A-2" [label="blocks"];`) || !strings.Contains(dot.String(), `label="A-1: Say \"hi\""`) {
+		t.Errorf("Unexpected DOT output:\n%s", dot.String())
+	}
+
+	var mermaid bytes.Buffer
+	if err := g.WriteMermaid(&mermaid); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if !strings.Contains(mermaid.String(), `n0 -- "blocks" --> n1`) || !strings.Contains(mermaid.String(), `n0["A-1: Say #quot;hi#quot;"]`) {
+		t.Errorf("Unexpected Mermaid output:\n%s", mermaid.String())
+	}
+}
+
+func TestIssueService_CrawlLinks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	results := map[string]string{
+		`project = A`: `[{"key":"A-1"}]`,
+		`key in (A-1)`: `[{"key":"A-1","fields":{"issuelinks":[
+			{"type":{"name":"Blocks","inward":"is blocked by","outward":"blocks"},"outwardIssue":{"key":"A-2"}},
+			{"type":{"name":"Relates","inward":"relates to","outward":"relates to"},"outwardIssue":{"key":"A-9"}}]}}]`,
+		`key in (A-2)`: `[{"key":"A-2","fields":{"summary":"Second","issuelinks":[
+			{"type":{"name":"Blocks","inward":"is blocked by","outward":"blocks"},"inwardIssue":{"key":"A-1"}},
+			{"type":{"name":"Blocks","inward":"is blocked by","outward":"blocks"},"outwardIssue":{"key":"A-3"}}]}}]`,
+	}
+	testMux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		jql := r.URL.Query().Get("jql")
+		issues, ok := results[jql]
+		if !ok {
+			t.Errorf("Unexpected JQL %q", jql)
+			issues = `[]`
+		}
+		fmt.Fprintf(w, `{"startAt":0,"maxResults":50,"total":1,"issues":%s}`, issues)
+	})
+
+	g, err := testClient.Issue.CrawlLinks(context.Background(), &LinkGraphOptions{JQL: "project = A", LinkTypes: []string{"blocks"}, MaxDepth: 1})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	want := []LinkEdge{
+		{From: "A-1", To: "A-2", Type: "Blocks", Label: "blocks"},
+		{From: "A-2", To: "A-3", Type: "Blocks", Label: "blocks"},
+	}
+	if !reflect.DeepEqual(g.Edges, want) {
+		t.Errorf("Expected edges %v, got %v", want, g.Edges)
+	}
+	if g.Issues["A-2"].Fields.Summary != "Second" {
+		t.Errorf("Expected A-2 to be fetched, got %+v", g.Issues["A-2"])
+	}
+	if _, ok := g.Issues["A-9"]; ok {
+		t.Error("Expected links of other types not to be followed")
+	}
+}
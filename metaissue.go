@@ -2,6 +2,7 @@ package jira
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -33,8 +34,11 @@ type AllowedValue struct {
 	Description    string `json:"description,omitempty"`
 	IconURL        string `json:"iconUrl,omitempty"`
 	Name           string `json:"name,omitempty"`
+	Value          string `json:"value,omitempty"`
 	Subtask        bool   `json:"subtask,omitempty"`
 	AvatarID       int    `json:"avatarId,omitempty"`
+	// Children are the options below this option of a cascading select field.
+	Children []AllowedValue `json:"children,omitempty"`
 }
 
 // ProjectIssueField JIRA v92
@@ -116,41 +120,68 @@ func (s *IssueService) GetCreateMeta(ctx context.Context, options *GetQueryOptio
 	return meta, resp, nil
 }
 
-// GetProjectIssueTypes JIRA v9.2
+// GetProjectIssueTypes returns the issue types which can be created in the project, fetching all pages.
+// The issue types hold no fields, see GetProjectIssueFields.
+// This endpoint was added in Jira 8.4 and replaces GetCreateMeta on Jira Data Center 9.0 and later.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/9.2.0/#api/2/issue-getCreateIssueMetaProjectIssueTypes
 func (s *IssueService) GetProjectIssueTypes(ctx context.Context, projectIdOrKey string) ([]MetaIssueType, *Response, error) {
 	apiEndpoint := fmt.Sprintf("rest/api/2/issue/createmeta/%s/issuetypes", projectIdOrKey)
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	types := new(ProjectIssueTypesResult)
-	resp, err := s.client.Do(req, types)
-
-	if err != nil {
-		return nil, resp, err
+	var types []MetaIssueType
+	var resp *Response
+	for startAt := 0; ; {
+		page := new(ProjectIssueTypesResult)
+		var err error
+		resp, err = s.getCreateMetaPage(ctx, apiEndpoint, startAt, page)
+		if err != nil {
+			return nil, resp, err
+		}
+		types = append(types, page.Values...)
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 || (page.Total > 0 && startAt >= page.Total) {
+			break
+		}
 	}
 
-	return types.Values, resp, nil
+	return types, resp, nil
 }
 
-// GetProjectIssueFields JIRA v9.2
+// GetProjectIssueFields returns the fields to create an issue of the issue type in the project, fetching all pages.
+// This endpoint was added in Jira 8.4 and replaces GetCreateMeta on Jira Data Center 9.0 and later.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/9.2.0/#api/2/issue-getCreateIssueMetaProjectIssueTypeFields
 func (s *IssueService) GetProjectIssueFields(ctx context.Context, projectIdOrKey string, issueTypeID string) ([]ProjectIssueField, *Response, error) {
 	apiEndpoint := fmt.Sprintf("rest/api/2/issue/createmeta/%s/issuetypes/%s", projectIdOrKey, issueTypeID)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
-	if err != nil {
-		return nil, nil, err
+
+	var fields []ProjectIssueField
+	var resp *Response
+	for startAt := 0; ; {
+		page := new(ProjectIssueFieldsResult)
+		var err error
+		resp, err = s.getCreateMetaPage(ctx, apiEndpoint, startAt, page)
+		if err != nil {
+			return nil, resp, err
+		}
+		fields = append(fields, page.Values...)
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 || (page.Total > 0 && startAt >= page.Total) {
+			break
+		}
 	}
 
-	fields := new(ProjectIssueFieldsResult)
-	resp, err := s.client.Do(req, fields)
+	return fields, resp, nil
+}
 
+func (s *IssueService) getCreateMetaPage(ctx context.Context, apiEndpoint string, startAt int, page interface{}) (*Response, error) {
+	if startAt > 0 {
+		apiEndpoint += fmt.Sprintf("?startAt=%d", startAt)
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
 	if err != nil {
-		return nil, resp, err
+		return nil, err
 	}
-
-	return fields.Values, resp, nil
+	return s.client.Do(req, page)
 }
 
 // GetEditMeta makes the api call to get the edit meta information for an issue
@@ -293,3 +324,114 @@ func (t *MetaIssueType) CheckCompleteAndAvailable(config map[string]string) (boo
 
 	return true, nil
 }
+
+// createTarget is the create metadata of an issue type in a project, with the fields keyed by field ID
+type createTarget struct {
+	projectID   string
+	projectKey  string
+	issueTypeID string
+	subtask     bool
+	fields      map[string]ProjectIssueField
+}
+
+// createTargets caches the create metadata of issue types by project key and issue type name
+type createTargets struct {
+	s       *IssueService
+	targets map[string]*createTarget
+}
+
+func newCreateTargets(s *IssueService) *createTargets {
+	return &createTargets{s: s, targets: make(map[string]*createTarget)}
+}
+
+// get returns the create metadata of the issue type in the project
+func (c *createTargets) get(ctx context.Context, project, issueType string) (*createTarget, error) {
+	cacheKey := project + "/" + strings.ToLower(issueType)
+	if t, ok := c.targets[cacheKey]; ok {
+		return t, nil
+	}
+
+	metaProject, metaIssueType, err := c.s.GetCreateMetaIssueType(ctx, project, issueType)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := metaIssueTypeFields(metaIssueType)
+	if err != nil {
+		return nil, err
+	}
+	t := &createTarget{
+		projectID:   metaProject.Id,
+		projectKey:  metaProject.Key,
+		issueTypeID: metaIssueType.Id,
+		subtask:     metaIssueType.Subtasks,
+		fields:      fields,
+	}
+	c.targets[cacheKey] = t
+	return t, nil
+}
+
+// subtaskType returns the name of the subtask issue type of the project
+func (c *createTargets) subtaskType(ctx context.Context, project string) (string, error) {
+	metaProject, err := c.s.GetCreateMetaProject(ctx, project)
+	if err != nil {
+		return "", err
+	}
+	for _, t := range metaProject.IssueTypes {
+		if t.Subtasks {
+			return t.Name, nil
+		}
+	}
+	return "", fmt.Errorf("project %s has no subtask issue type", project)
+}
+
+// projectRef references the project when creating an issue, by ID if known
+func (t *createTarget) projectRef() map[string]interface{} {
+	if t.projectID != "" {
+		return map[string]interface{}{"id": t.projectID}
+	}
+	return map[string]interface{}{"key": t.projectKey}
+}
+
+// field returns the field with the given ID or, case insensitive, name
+func (t *createTarget) field(idOrName string) (ProjectIssueField, bool) {
+	return findMetaField(t.fields, idOrName)
+}
+
+// findMetaField returns the field with the given ID or, case insensitive, name
+func findMetaField(fields map[string]ProjectIssueField, idOrName string) (ProjectIssueField, bool) {
+	if f, ok := fields[idOrName]; ok {
+		return f, true
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, idOrName) {
+			return f, true
+		}
+	}
+	return ProjectIssueField{}, false
+}
+
+// metaIssueTypeFields decodes the fields of the create metadata of an issue type
+func metaIssueTypeFields(t *MetaIssueType) (map[string]ProjectIssueField, error) {
+	return decodeMetaFields(t.Fields)
+}
+
+// decodeMetaFields decodes the fields of create or edit metadata, keyed by field ID
+func decodeMetaFields(m tcontainer.MarshalMap) (map[string]ProjectIssueField, error) {
+	fields := make(map[string]ProjectIssueField, len(m))
+	for id, raw := range m {
+		b, err := json.Marshal(raw)
+		if err != nil {
+			return nil, err
+		}
+		var field ProjectIssueField
+		if err := json.Unmarshal(b, &field); err != nil {
+			return nil, fmt.Errorf("decoding metadata of field %s: %w", id, err)
+		}
+		if field.FieldID == "" {
+			field.FieldID = id
+		}
+		fields[id] = field
+	}
+	return fields, nil
+}
@@ -218,26 +218,12 @@ func (s *OrganizationService) GetProperty(ctx context.Context, organizationID in
 // SetProperty sets the value of a
 // property for an organization. Use this
 // resource to store custom data against an organization.
+// The value must be serializable to JSON.
 //
 // https://developer.atlassian.com/cloud/jira/service-desk/rest/api-group-organization/#api-rest-servicedeskapi-organization-organizationid-property-propertykey-put
 // Caller must close resp.Body
-func (s *OrganizationService) SetProperty(ctx context.Context, organizationID int, propertyKey string) (*Response, error) {
-	apiEndPoint := fmt.Sprintf("rest/servicedeskapi/organization/%d/property/%s", organizationID, propertyKey)
-
-	req, err := s.client.NewRequest(ctx, http.MethodPut, apiEndPoint, nil)
-	req.Header.Set("Accept", "application/json")
-
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.Do(req, nil)
-	if err != nil {
-		jerr := NewJiraError(resp, err)
-		return resp, jerr
-	}
-
-	return resp, nil
+func (s *OrganizationService) SetProperty(ctx context.Context, organizationID int, propertyKey string, value interface{}) (*Response, error) {
+	return s.client.Property.Set(ctx, OrganizationEntity(organizationID), propertyKey, value)
 }
 
 // DeleteProperty removes a property from an organization.
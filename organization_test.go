@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"testing"
 )
@@ -170,11 +171,15 @@ func TestOrganizationService_SetProperty(t *testing.T) {
 		testMethod(t, r, http.MethodPut)
 		testRequestURL(t, r, "/rest/servicedeskapi/organization/1/property/organization.attributes")
 
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"phone":"0800-1234"}`+"\n" {
+			t.Errorf("Unexpected property value %s", body)
+		}
 		w.WriteHeader(http.StatusOK)
 	})
 
 	key := "organization.attributes"
-	_, err := testClient.Organization.SetProperty(context.Background(), 1, key)
+	_, err := testClient.Organization.SetProperty(context.Background(), 1, key, map[string]string{"phone": "0800-1234"})
 
 	if err != nil {
 		t.Errorf("Error given: %s", err)
@@ -0,0 +1,208 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Keys of common project permissions
+const (
+	PermissionBrowseProjects     = "BROWSE_PROJECTS"
+	PermissionCreateIssues       = "CREATE_ISSUES"
+	PermissionEditIssues         = "EDIT_ISSUES"
+	PermissionTransitionIssues   = "TRANSITION_ISSUES"
+	PermissionAssignIssues       = "ASSIGN_ISSUES"
+	PermissionResolveIssues      = "RESOLVE_ISSUES"
+	PermissionDeleteIssues       = "DELETE_ISSUES"
+	PermissionAddComments        = "ADD_COMMENTS"
+	PermissionWorkOnIssues       = "WORK_ON_ISSUES"
+	PermissionAdministerProjects = "ADMINISTER_PROJECTS"
+)
+
+// UserPermission is a permission of the current user returned by PermissionSchemeService.GetMyPermissions.
+type UserPermission struct {
+	ID             string `json:"id" structs:"id"`
+	Key            string `json:"key" structs:"key"`
+	Name           string `json:"name" structs:"name"`
+	Type           string `json:"type" structs:"type"`
+	Description    string `json:"description" structs:"description"`
+	HavePermission bool   `json:"havePermission" structs:"havePermission"`
+}
+
+// MyPermissionsOptions specifies the optional parameters to PermissionSchemeService.GetMyPermissions
+type MyPermissionsOptions struct {
+	// ProjectKey or ProjectID: The project to check the permissions in.
+	ProjectKey string `url:"projectKey,omitempty"`
+	ProjectID  string `url:"projectId,omitempty"`
+	// IssueKey or IssueID: The issue to check the permissions for, e.g. for issue level security.
+	IssueKey string `url:"issueKey,omitempty"`
+	IssueID  string `url:"issueId,omitempty"`
+	// Permissions: The keys of the permissions to check, e.g. PermissionEditIssues. Required on Jira Cloud.
+	Permissions []string `url:"permissions,comma,omitempty"`
+}
+
+// GetMyPermissions returns the permissions of the current user by permission key, globally or in a project or issue.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-permissions/#api-rest-api-2-mypermissions-get
+func (s *PermissionSchemeService) GetMyPermissions(ctx context.Context, options *MyPermissionsOptions) (map[string]UserPermission, *Response, error) {
+	apiEndpoint := "rest/api/2/mypermissions"
+	url, err := addOptions(apiEndpoint, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(struct {
+		Permissions map[string]UserPermission `json:"permissions"`
+	})
+	resp, err := s.client.Do(req, result)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return result.Permissions, resp, nil
+}
+
+// ProjectPermissionsCheck asks for project permissions in projects and issues.
+type ProjectPermissionsCheck struct {
+	Permissions []string `json:"permissions" structs:"permissions"`
+	Projects    []int    `json:"projects,omitempty" structs:"projects,omitempty"`
+	Issues      []int    `json:"issues,omitempty" structs:"issues,omitempty"`
+}
+
+// PermissionsCheck asks which permissions a user has, see PermissionSchemeService.CheckPermissions.
+type PermissionsCheck struct {
+	// AccountID is the user to check. Default: the current user.
+	AccountID          string                    `json:"accountId,omitempty" structs:"accountId,omitempty"`
+	GlobalPermissions  []string                  `json:"globalPermissions,omitempty" structs:"globalPermissions,omitempty"`
+	ProjectPermissions []ProjectPermissionsCheck `json:"projectPermissions,omitempty" structs:"projectPermissions,omitempty"`
+}
+
+// ProjectPermissionsGranted lists the projects and issues in which a user has a permission.
+type ProjectPermissionsGranted struct {
+	Permission string `json:"permission" structs:"permission"`
+	Projects   []int  `json:"projects" structs:"projects"`
+	Issues     []int  `json:"issues" structs:"issues"`
+}
+
+// PermissionsCheckResult lists the permissions of a PermissionsCheck the user has.
+type PermissionsCheckResult struct {
+	GlobalPermissions  []string                    `json:"globalPermissions" structs:"globalPermissions"`
+	ProjectPermissions []ProjectPermissionsGranted `json:"projectPermissions" structs:"projectPermissions"`
+}
+
+// CheckPermissions returns which of the global and project permissions a user has.
+// The endpoint is only available on Jira Cloud.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-permissions/#api-rest-api-2-permissions-check-post
+func (s *PermissionSchemeService) CheckPermissions(ctx context.Context, check *PermissionsCheck) (*PermissionsCheckResult, *Response, error) {
+	apiEndpoint := "rest/api/2/permissions/check"
+	req, err := s.client.NewRequest(ctx, http.MethodPost, apiEndpoint, check)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(PermissionsCheckResult)
+	resp, err := s.client.Do(req, result)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return result, resp, nil
+}
+
+// HasProjectPermission reports whether the user with the account ID has the permission in the project,
+// e.g. whether they may transition issues with PermissionTransitionIssues. The endpoint is only available on Jira Cloud;
+// UserHasProjectPermission works on Jira Server / Data Center, too.
+func (s *PermissionSchemeService) HasProjectPermission(ctx context.Context, accountID string, projectID int, permission string) (bool, error) {
+	result, _, err := s.CheckPermissions(ctx, &PermissionsCheck{
+		AccountID:          accountID,
+		ProjectPermissions: []ProjectPermissionsCheck{{Permissions: []string{permission}, Projects: []int{projectID}}},
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, granted := range result.ProjectPermissions {
+		if granted.Permission != permission {
+			continue
+		}
+		for _, id := range granted.Projects {
+			if id == projectID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// UserPermissionSearchOptions specifies the parameters to PermissionSchemeService.FindUsersWithPermissions.
+// One of ProjectKey and IssueKey is required.
+type UserPermissionSearchOptions struct {
+	// Permissions are the keys of the permissions the users must have, e.g. PermissionEditIssues.
+	Permissions []string `url:"permissions,comma"`
+	// Username matches the beginning of user names, display names and email addresses on Jira Server / Data Center.
+	Username string `url:"username,omitempty"`
+	// AccountID selects a single user on Jira Cloud.
+	AccountID  string `url:"accountId,omitempty"`
+	ProjectKey string `url:"projectKey,omitempty"`
+	IssueKey   string `url:"issueKey,omitempty"`
+	StartAt    int    `url:"startAt,omitempty"`
+	MaxResults int    `url:"maxResults,omitempty"`
+}
+
+// FindUsersWithPermissions returns the users that have all the permissions in the project or issue.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/user-findUsersWithAllPermissions
+func (s *PermissionSchemeService) FindUsersWithPermissions(ctx context.Context, options *UserPermissionSearchOptions) ([]User, *Response, error) {
+	apiEndpoint := "rest/api/2/user/permission/search"
+	url, err := addOptions(apiEndpoint, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var users []User
+	resp, err := s.client.Do(req, &users)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return users, resp, nil
+}
+
+// UserHasProjectPermission reports whether the user has the permission in the project with the key,
+// e.g. whether they may transition issues with PermissionTransitionIssues.
+// The user is identified by account ID on Jira Cloud and by name on Jira Server / Data Center.
+func (s *PermissionSchemeService) UserHasProjectPermission(ctx context.Context, user *User, projectKey, permission string) (bool, error) {
+	param, value, err := (*IssueService)(s).userParam(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	options := &UserPermissionSearchOptions{Permissions: []string{permission}, ProjectKey: projectKey, MaxResults: 100}
+	if param == "accountId" {
+		options.AccountID = value
+	} else {
+		options.Username = value
+	}
+
+	// The user name is a search term that may match other users, too
+	for {
+		users, _, err := s.FindUsersWithPermissions(ctx, options)
+		if err != nil {
+			return false, err
+		}
+		for i := range users {
+			if (param == "accountId" && users[i].AccountID == value) || (param == "username" && strings.EqualFold(users[i].Name, value)) {
+				return true, nil
+			}
+		}
+		if len(users) < options.MaxResults {
+			return false, nil
+		}
+		options.StartAt += len(users)
+	}
+}
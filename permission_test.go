@@ -0,0 +1,93 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestPermissionSchemeService_GetMyPermissions(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/mypermissions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testRequestURL(t, r, "/rest/api/2/mypermissions?permissions=EDIT_ISSUES%2CTRANSITION_ISSUES&projectKey=OPS")
+		fmt.Fprint(w, `{"permissions":{
+			"EDIT_ISSUES":{"id":"12","key":"EDIT_ISSUES","name":"Edit Issues","type":"PROJECT","havePermission":true},
+			"TRANSITION_ISSUES":{"id":"46","key":"TRANSITION_ISSUES","name":"Transition Issues","type":"PROJECT","havePermission":false}}}`)
+	})
+
+	permissions, _, err := testClient.PermissionScheme.GetMyPermissions(context.Background(), &MyPermissionsOptions{
+		ProjectKey:  "OPS",
+		Permissions: []string{PermissionEditIssues, PermissionTransitionIssues},
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if !permissions[PermissionEditIssues].HavePermission || permissions[PermissionTransitionIssues].HavePermission {
+		t.Errorf("Unexpected permissions %+v", permissions)
+	}
+}
+
+func TestPermissionSchemeService_HasProjectPermission(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/permissions/check", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		var check PermissionsCheck
+		if err := json.NewDecoder(r.Body).Decode(&check); err != nil {
+			t.Fatal(err)
+		}
+		want := PermissionsCheck{
+			AccountID:          "abc",
+			ProjectPermissions: []ProjectPermissionsCheck{{Permissions: []string{check.ProjectPermissions[0].Permissions[0]}, Projects: []int{10001}}},
+		}
+		if !reflect.DeepEqual(check, want) {
+			t.Errorf("Expected check %+v, got %+v", want, check)
+		}
+		fmt.Fprint(w, `{"globalPermissions":[],"projectPermissions":[
+			{"permission":"EDIT_ISSUES","projects":[10001],"issues":[]},
+			{"permission":"TRANSITION_ISSUES","projects":[],"issues":[]}]}`)
+	})
+
+	for permission, want := range map[string]bool{PermissionEditIssues: true, PermissionTransitionIssues: false} {
+		got, err := testClient.PermissionScheme.HasProjectPermission(context.Background(), "abc", 10001, permission)
+		if err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+		if got != want {
+			t.Errorf("Expected %s to be %v, got %v", permission, want, got)
+		}
+	}
+}
+
+func TestPermissionSchemeService_UserHasProjectPermission(t *testing.T) {
+	setup()
+	defer teardown()
+	handleServerInfo(t, DeploymentTypeServer)
+	testMux.HandleFunc("/rest/api/2/user/permission/search", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		query := r.URL.Query()
+		if query.Get("username") != "fred" || query.Get("projectKey") != "OPS" {
+			t.Errorf("Unexpected query %q", r.URL.RawQuery)
+		}
+		if query.Get("permissions") == PermissionEditIssues {
+			fmt.Fprint(w, `[{"name":"freddy"},{"name":"Fred"}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"name":"freddy"}]`)
+	})
+
+	for permission, want := range map[string]bool{PermissionEditIssues: true, PermissionTransitionIssues: false} {
+		got, err := testClient.PermissionScheme.UserHasProjectPermission(context.Background(), &User{Name: "fred"}, "OPS", permission)
+		if err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+		if got != want {
+			t.Errorf("Expected %s to be %v, got %v", permission, want, got)
+		}
+	}
+}
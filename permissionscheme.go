@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
 )
 
 // PermissionSchemeService handles permissionschemes for the Jira instance / API.
@@ -25,7 +26,9 @@ type Permission struct {
 type Holder struct {
 	Type      string `json:"type" structs:"type"`
 	Parameter string `json:"parameter" structs:"parameter"`
-	Expand    string `json:"expand" structs:"expand"`
+	// Value identifies the holder on Jira Cloud, e.g. the group ID of a group holder.
+	Value  string `json:"value,omitempty" structs:"value,omitempty"`
+	Expand string `json:"expand" structs:"expand"`
 }
 
 // GetList returns a list of all permission schemes
@@ -70,3 +73,257 @@ func (s *PermissionSchemeService) Get(ctx context.Context, schemeID int) (*Permi
 
 	return ps, resp, nil
 }
+
+// permissionGrantRequest is the body to create a permission grant.
+// Permission always sends its ID, which Jira rejects on create.
+type permissionGrantRequest struct {
+	Holder     permissionHolderRequest `json:"holder"`
+	Permission string                  `json:"permission"`
+}
+
+type permissionHolderRequest struct {
+	Type      string `json:"type"`
+	Parameter string `json:"parameter,omitempty"`
+	Value     string `json:"value,omitempty"`
+}
+
+func newPermissionGrantRequest(p *Permission) permissionGrantRequest {
+	return permissionGrantRequest{
+		Holder:     permissionHolderRequest{Type: p.Holder.Type, Parameter: p.Holder.Parameter, Value: p.Holder.Value},
+		Permission: p.Name,
+	}
+}
+
+// permissionSchemeRequest is the body to create or update a permission scheme
+type permissionSchemeRequest struct {
+	Name        string                   `json:"name,omitempty"`
+	Description string                   `json:"description,omitempty"`
+	Permissions []permissionGrantRequest `json:"permissions,omitempty"`
+}
+
+func newPermissionSchemeRequest(scheme *PermissionScheme) *permissionSchemeRequest {
+	body := &permissionSchemeRequest{Name: scheme.Name, Description: scheme.Description}
+	for i := range scheme.Permissions {
+		body.Permissions = append(body.Permissions, newPermissionGrantRequest(&scheme.Permissions[i]))
+	}
+	return body
+}
+
+// Create creates a permission scheme with the name, description and permission grants of scheme.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/permissionscheme-createPermissionScheme
+func (s *PermissionSchemeService) Create(ctx context.Context, scheme *PermissionScheme) (*PermissionScheme, *Response, error) {
+	apiEndpoint := "rest/api/2/permissionscheme"
+	req, err := s.client.NewRequest(ctx, http.MethodPost, apiEndpoint, newPermissionSchemeRequest(scheme))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	created := new(PermissionScheme)
+	resp, err := s.client.Do(req, created)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return created, resp, nil
+}
+
+// Update changes the name and description of the permission scheme with the ID of scheme.
+// If scheme has permission grants, they replace all grants of the scheme; otherwise the grants are left unchanged.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/permissionscheme-updatePermissionScheme
+func (s *PermissionSchemeService) Update(ctx context.Context, scheme *PermissionScheme) (*PermissionScheme, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/permissionscheme/%d", scheme.ID)
+	req, err := s.client.NewRequest(ctx, http.MethodPut, apiEndpoint, newPermissionSchemeRequest(scheme))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated := new(PermissionScheme)
+	resp, err := s.client.Do(req, updated)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return updated, resp, nil
+}
+
+// Delete deletes a permission scheme.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/permissionscheme-deletePermissionScheme
+// Caller must close resp.Body
+func (s *PermissionSchemeService) Delete(ctx context.Context, schemeID int) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/permissionscheme/%d", schemeID)
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, apiEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, NewJiraError(resp, err)
+	}
+	return resp, nil
+}
+
+// GetGrants returns all permission grants of a permission scheme.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/permissionscheme-getPermissionSchemeGrants
+func (s *PermissionSchemeService) GetGrants(ctx context.Context, schemeID int) ([]Permission, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/permissionscheme/%d/permission", schemeID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	grants := new(struct {
+		Permissions []Permission `json:"permissions"`
+	})
+	resp, err := s.client.Do(req, grants)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return grants.Permissions, resp, nil
+}
+
+// CreateGrant adds a permission grant to a permission scheme, e.g.
+// &Permission{Name: PermissionEditIssues, Holder: Holder{Type: "projectRole", Parameter: "10002"}}.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/permissionscheme-createPermissionGrant
+func (s *PermissionSchemeService) CreateGrant(ctx context.Context, schemeID int, grant *Permission) (*Permission, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/permissionscheme/%d/permission", schemeID)
+	req, err := s.client.NewRequest(ctx, http.MethodPost, apiEndpoint, newPermissionGrantRequest(grant))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	created := new(Permission)
+	resp, err := s.client.Do(req, created)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return created, resp, nil
+}
+
+// DeleteGrant removes a permission grant from a permission scheme.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/permissionscheme-deletePermissionSchemeEntity
+// Caller must close resp.Body
+func (s *PermissionSchemeService) DeleteGrant(ctx context.Context, schemeID, grantID int) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/permissionscheme/%d/permission/%d", schemeID, grantID)
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, apiEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, NewJiraError(resp, err)
+	}
+	return resp, nil
+}
+
+// AssignToProject makes the permission scheme the scheme of the project and returns the assigned scheme.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/project/{projectKeyOrId}/permissionscheme-assignPermissionScheme
+func (s *PermissionSchemeService) AssignToProject(ctx context.Context, projectID string, schemeID int) (*PermissionScheme, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/project/%s/permissionscheme", projectID)
+	req, err := s.client.NewRequest(ctx, http.MethodPut, apiEndpoint, map[string]int{"id": schemeID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scheme := new(PermissionScheme)
+	resp, err := s.client.Do(req, scheme)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return scheme, resp, nil
+}
+
+// PermissionGrant is a permission granted to a holder, independent of the scheme and grant ID.
+type PermissionGrant struct {
+	Permission string
+	// HolderType is the type of holder, e.g. "group", "projectRole", "user" or "anyone".
+	HolderType string
+	// Holder is the parameter of the holder, e.g. the group name or project role ID, or its value if it has no parameter.
+	Holder string
+}
+
+func (g PermissionGrant) String() string {
+	if g.Holder == "" {
+		return fmt.Sprintf("%s to %s", g.Permission, g.HolderType)
+	}
+	return fmt.Sprintf("%s to %s %s", g.Permission, g.HolderType, g.Holder)
+}
+
+func newPermissionGrant(p *Permission) PermissionGrant {
+	holder := p.Holder.Parameter
+	if holder == "" {
+		holder = p.Holder.Value
+	}
+	return PermissionGrant{Permission: p.Name, HolderType: p.Holder.Type, Holder: holder}
+}
+
+// PermissionSchemeDiff lists the grants which differ between two permission schemes.
+type PermissionSchemeDiff struct {
+	// Added are the grants only in the second scheme.
+	Added []PermissionGrant
+	// Removed are the grants only in the first scheme.
+	Removed []PermissionGrant
+}
+
+// Empty reports whether both schemes grant the same permissions.
+func (d *PermissionSchemeDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// DiffPermissionGrants compares the grants of two permission schemes. The grants are sorted by permission and holder.
+func DiffPermissionGrants(from, to []Permission) *PermissionSchemeDiff {
+	fromGrants, toGrants := map[PermissionGrant]bool{}, map[PermissionGrant]bool{}
+	for i := range from {
+		fromGrants[newPermissionGrant(&from[i])] = true
+	}
+	for i := range to {
+		toGrants[newPermissionGrant(&to[i])] = true
+	}
+
+	diff := &PermissionSchemeDiff{}
+	for g := range toGrants {
+		if !fromGrants[g] {
+			diff.Added = append(diff.Added, g)
+		}
+	}
+	for g := range fromGrants {
+		if !toGrants[g] {
+			diff.Removed = append(diff.Removed, g)
+		}
+	}
+	sortPermissionGrants(diff.Added)
+	sortPermissionGrants(diff.Removed)
+	return diff
+}
+
+func sortPermissionGrants(grants []PermissionGrant) {
+	sort.Slice(grants, func(i, j int) bool {
+		a, b := grants[i], grants[j]
+		if a.Permission != b.Permission {
+			return a.Permission < b.Permission
+		}
+		if a.HolderType != b.HolderType {
+			return a.HolderType < b.HolderType
+		}
+		return a.Holder < b.Holder
+	})
+}
+
+// Diff compares the grants of two permission schemes.
+func (s *PermissionSchemeService) Diff(ctx context.Context, fromSchemeID, toSchemeID int) (*PermissionSchemeDiff, error) {
+	from, _, err := s.GetGrants(ctx, fromSchemeID)
+	if err != nil {
+		return nil, err
+	}
+	to, _, err := s.GetGrants(ctx, toSchemeID)
+	if err != nil {
+		return nil, err
+	}
+	return DiffPermissionGrants(from, to), nil
+}
@@ -3,8 +3,11 @@ package jira
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -105,3 +108,146 @@ func TestPermissionSchemeService_Get_NoScheme(t *testing.T) {
 		t.Errorf("No error given")
 	}
 }
+
+func TestPermissionSchemeService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/permissionscheme", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		b, _ := io.ReadAll(r.Body)
+		want := `{"name":"Restricted","permissions":[{"holder":{"type":"projectRole","parameter":"10002"},"permission":"EDIT_ISSUES"}]}`
+		if strings.TrimSpace(string(b)) != want {
+			t.Errorf("Expected body %s, got %s", want, b)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":10100,"self":"https://jira.example.com/rest/api/2/permissionscheme/10100","name":"Restricted"}`)
+	})
+
+	scheme, _, err := testClient.PermissionScheme.Create(context.Background(), &PermissionScheme{
+		Name:        "Restricted",
+		Permissions: []Permission{{Name: PermissionEditIssues, Holder: Holder{Type: "projectRole", Parameter: "10002"}}},
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if scheme.ID != 10100 {
+		t.Errorf("Unexpected scheme %+v", scheme)
+	}
+}
+
+func TestPermissionSchemeService_UpdateDelete(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/permissionscheme/10100", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			b, _ := io.ReadAll(r.Body)
+			if strings.TrimSpace(string(b)) != `{"description":"Only developers edit"}` {
+				t.Errorf("Unexpected body %s", b)
+			}
+			fmt.Fprint(w, `{"id":10100,"name":"Restricted","description":"Only developers edit"}`)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+
+	scheme, _, err := testClient.PermissionScheme.Update(context.Background(), &PermissionScheme{ID: 10100, Description: "Only developers edit"})
+	if err != nil || scheme.Description != "Only developers edit" {
+		t.Errorf("Unexpected scheme %+v, %v", scheme, err)
+	}
+	if _, err := testClient.PermissionScheme.Delete(context.Background(), 10100); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestPermissionSchemeService_Grants(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/permissionscheme/10100/permission", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"permissions":[{"id":1,"holder":{"type":"group","parameter":"developers"},"permission":"EDIT_ISSUES"}]}`)
+		case http.MethodPost:
+			b, _ := io.ReadAll(r.Body)
+			if strings.TrimSpace(string(b)) != `{"holder":{"type":"anyone"},"permission":"BROWSE_PROJECTS"}` {
+				t.Errorf("Unexpected body %s", b)
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":2,"holder":{"type":"anyone"},"permission":"BROWSE_PROJECTS"}`)
+		}
+	})
+	testMux.HandleFunc("/rest/api/2/permissionscheme/10100/permission/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	grants, _, err := testClient.PermissionScheme.GetGrants(ctx, 10100)
+	if err != nil || len(grants) != 1 || grants[0].Holder.Parameter != "developers" {
+		t.Errorf("Unexpected grants %+v, %v", grants, err)
+	}
+	grant, _, err := testClient.PermissionScheme.CreateGrant(ctx, 10100, &Permission{Name: PermissionBrowseProjects, Holder: Holder{Type: "anyone"}})
+	if err != nil || grant.ID != 2 {
+		t.Fatalf("Unexpected grant %+v, %v", grant, err)
+	}
+	if _, err := testClient.PermissionScheme.DeleteGrant(ctx, 10100, grant.ID); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestPermissionSchemeService_AssignToProject(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/project/OPS/permissionscheme", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		b, _ := io.ReadAll(r.Body)
+		if strings.TrimSpace(string(b)) != `{"id":10100}` {
+			t.Errorf("Unexpected body %s", b)
+		}
+		fmt.Fprint(w, `{"id":10100,"name":"Restricted"}`)
+	})
+
+	scheme, _, err := testClient.PermissionScheme.AssignToProject(context.Background(), "OPS", 10100)
+	if err != nil || scheme.Name != "Restricted" {
+		t.Errorf("Unexpected scheme %+v, %v", scheme, err)
+	}
+}
+
+func TestPermissionSchemeService_Diff(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/permissionscheme/1/permission", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"permissions":[
+			{"id":1,"holder":{"type":"group","parameter":"developers"},"permission":"EDIT_ISSUES"},
+			{"id":2,"holder":{"type":"anyone"},"permission":"BROWSE_PROJECTS"}]}`)
+	})
+	testMux.HandleFunc("/rest/api/2/permissionscheme/2/permission", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"permissions":[
+			{"id":7,"holder":{"type":"group","parameter":"developers"},"permission":"EDIT_ISSUES"},
+			{"id":8,"holder":{"type":"projectRole","parameter":"10002"},"permission":"BROWSE_PROJECTS"},
+			{"id":9,"holder":{"type":"group","value":"7c2b-group-id"},"permission":"ADD_COMMENTS"}]}`)
+	})
+
+	diff, err := testClient.PermissionScheme.Diff(context.Background(), 1, 2)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	var added, removed []string
+	for _, g := range diff.Added {
+		added = append(added, g.String())
+	}
+	for _, g := range diff.Removed {
+		removed = append(removed, g.String())
+	}
+	if want := []string{"ADD_COMMENTS to group 7c2b-group-id", "BROWSE_PROJECTS to projectRole 10002"}; !reflect.DeepEqual(added, want) {
+		t.Errorf("Expected added %q, got %q", want, added)
+	}
+	if want := []string{"BROWSE_PROJECTS to anyone"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("Expected removed %q, got %q", want, removed)
+	}
+	if diff.Empty() || !DiffPermissionGrants(nil, nil).Empty() {
+		t.Error("Unexpected result of Empty")
+	}
+}
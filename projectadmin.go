@@ -0,0 +1,486 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Project type keys of ProjectDetails.ProjectTypeKey
+const (
+	ProjectTypeSoftware         = "software"
+	ProjectTypeServiceDesk      = "service_desk"
+	ProjectTypeBusiness         = "business"
+	ProjectTypeProductDiscovery = "product_discovery"
+)
+
+// Template keys of ProjectDetails.ProjectTemplateKey for company-managed projects
+const (
+	ProjectTemplateScrum         = "com.pyxis.greenhopper.jira:gh-simplified-scrum-classic"
+	ProjectTemplateKanban        = "com.pyxis.greenhopper.jira:gh-simplified-kanban-classic"
+	ProjectTemplateBasicSoftware = "com.pyxis.greenhopper.jira:gh-simplified-basic"
+	ProjectTemplateTaskTracking  = "com.atlassian.jira-core-project-templates:jira-core-simplified-task-tracking"
+)
+
+// ProjectDetails are the settings of a project to create with ProjectService.Create or to change
+// with ProjectService.Update. Empty fields are not sent, so Update leaves them unchanged.
+type ProjectDetails struct {
+	Key         string `json:"key,omitempty" structs:"key,omitempty"`
+	Name        string `json:"name,omitempty" structs:"name,omitempty"`
+	Description string `json:"description,omitempty" structs:"description,omitempty"`
+	URL         string `json:"url,omitempty" structs:"url,omitempty"`
+	// ProjectTypeKey is one of the ProjectType* constants. Required on create.
+	ProjectTypeKey string `json:"projectTypeKey,omitempty" structs:"projectTypeKey,omitempty"`
+	// ProjectTemplateKey creates the project from a template, e.g. ProjectTemplateScrum. Only used on create.
+	ProjectTemplateKey string `json:"projectTemplateKey,omitempty" structs:"projectTemplateKey,omitempty"`
+	// Lead is the user name of the project lead on Jira Server and Data Center.
+	Lead string `json:"lead,omitempty" structs:"lead,omitempty"`
+	// LeadAccountID is the account ID of the project lead on Jira Cloud.
+	LeadAccountID string `json:"leadAccountId,omitempty" structs:"leadAccountId,omitempty"`
+	// AssigneeType is "PROJECT_LEAD" or "UNASSIGNED".
+	AssigneeType             string `json:"assigneeType,omitempty" structs:"assigneeType,omitempty"`
+	AvatarID                 int    `json:"avatarId,omitempty" structs:"avatarId,omitempty"`
+	CategoryID               int    `json:"categoryId,omitempty" structs:"categoryId,omitempty"`
+	PermissionScheme         int    `json:"permissionScheme,omitempty" structs:"permissionScheme,omitempty"`
+	NotificationScheme       int    `json:"notificationScheme,omitempty" structs:"notificationScheme,omitempty"`
+	IssueSecurityScheme      int    `json:"issueSecurityScheme,omitempty" structs:"issueSecurityScheme,omitempty"`
+	WorkflowScheme           int    `json:"workflowScheme,omitempty" structs:"workflowScheme,omitempty"`
+	IssueTypeScheme          int    `json:"issueTypeScheme,omitempty" structs:"issueTypeScheme,omitempty"`
+	IssueTypeScreenScheme    int    `json:"issueTypeScreenScheme,omitempty" structs:"issueTypeScreenScheme,omitempty"`
+	FieldConfigurationScheme int    `json:"fieldConfigurationScheme,omitempty" structs:"fieldConfigurationScheme,omitempty"`
+}
+
+// ProjectIdentifiers identifies a created project.
+type ProjectIdentifiers struct {
+	Self string `json:"self" structs:"self"`
+	ID   int    `json:"id" structs:"id"`
+	Key  string `json:"key" structs:"key"`
+}
+
+// Create creates a project.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-projects/#api-rest-api-2-project-post
+func (s *ProjectService) Create(ctx context.Context, project *ProjectDetails) (*ProjectIdentifiers, *Response, error) {
+	apiEndpoint := "rest/api/2/project"
+	req, err := s.client.NewRequest(ctx, http.MethodPost, apiEndpoint, project)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	created := new(ProjectIdentifiers)
+	resp, err := s.client.Do(req, created)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return created, resp, nil
+}
+
+// Update changes the settings of a project and returns the updated project.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-projects/#api-rest-api-2-project-projectidorkey-put
+func (s *ProjectService) Update(ctx context.Context, projectID string, project *ProjectDetails) (*Project, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/project/%s", projectID)
+	req, err := s.client.NewRequest(ctx, http.MethodPut, apiEndpoint, project)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated := new(Project)
+	resp, err := s.client.Do(req, updated)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return updated, resp, nil
+}
+
+// Delete deletes a project including all its issues.
+// Jira Cloud moves the project to the recycle bin, from which it can be restored with Restore.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-projects/#api-rest-api-2-project-projectidorkey-delete
+// Caller must close resp.Body
+func (s *ProjectService) Delete(ctx context.Context, projectID string) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/project/%s", projectID)
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, apiEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, NewJiraError(resp, err)
+	}
+	return resp, nil
+}
+
+// Archive archives a project. Archived projects are read-only and hidden from most views.
+// Jira Cloud uses POST and Jira Data Center PUT for this endpoint; the method is chosen by IsCloud.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-projects/#api-rest-api-2-project-projectidorkey-archive-post
+func (s *ProjectService) Archive(ctx context.Context, projectID string) (*Response, error) {
+	return s.changeArchiveState(ctx, projectID, "archive")
+}
+
+// Restore restores an archived project, or on Jira Cloud a deleted project from the recycle bin.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-projects/#api-rest-api-2-project-projectidorkey-restore-post
+func (s *ProjectService) Restore(ctx context.Context, projectID string) (*Response, error) {
+	return s.changeArchiveState(ctx, projectID, "restore")
+}
+
+func (s *ProjectService) changeArchiveState(ctx context.Context, projectID, action string) (*Response, error) {
+	cloud, err := s.client.Configuration.IsCloud(ctx)
+	if err != nil {
+		return nil, err
+	}
+	method := http.MethodPut
+	if cloud {
+		method = http.MethodPost
+	}
+
+	apiEndpoint := fmt.Sprintf("rest/api/2/project/%s/%s", projectID, action)
+	req, err := s.client.NewRequest(ctx, method, apiEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, NewJiraError(resp, err)
+	}
+	return resp, nil
+}
+
+// ProjectSearchOptions specifies the optional parameters to ProjectService.Search
+type ProjectSearchOptions struct {
+	// StartAt: The index of the first project to return. Base index: 0.
+	StartAt int `url:"startAt,omitempty"`
+	// MaxResults: The maximum number of projects to return per page. Default: 50.
+	MaxResults int `url:"maxResults,omitempty"`
+	// OrderBy: The field to sort projects by, e.g. "key", "name", "category" or "-lastIssueUpdatedTime".
+	OrderBy string `url:"orderBy,omitempty"`
+	// Query: Only projects whose key or name contain the string.
+	Query string `url:"query,omitempty"`
+	// TypeKey: Only projects of the comma separated project types.
+	TypeKey string `url:"typeKey,omitempty"`
+	// CategoryID: Only projects of the category.
+	CategoryID int `url:"categoryId,omitempty"`
+	// Action: Only projects the user may "view", "browse" or "edit".
+	Action string `url:"action,omitempty"`
+	// Status: Only projects with one of the statuses "live", "archived" or "deleted".
+	Status []string `url:"status,omitempty"`
+	// Expand: Comma separated list of "description", "projectKeys", "lead", "issueTypes", "url" and "insight".
+	Expand string `url:"expand,omitempty"`
+}
+
+// ProjectSearchResult is a page of projects returned by ProjectService.Search.
+type ProjectSearchResult struct {
+	Self       string    `json:"self" structs:"self"`
+	NextPage   string    `json:"nextPage" structs:"nextPage"`
+	StartAt    int       `json:"startAt" structs:"startAt"`
+	MaxResults int       `json:"maxResults" structs:"maxResults"`
+	Total      int       `json:"total" structs:"total"`
+	IsLast     bool      `json:"isLast" structs:"isLast"`
+	Values     []Project `json:"values" structs:"values"`
+}
+
+// Search returns a page of projects matching the options. The endpoint is only available on Jira Cloud.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-projects/#api-rest-api-2-project-search-get
+func (s *ProjectService) Search(ctx context.Context, options *ProjectSearchOptions) (*ProjectSearchResult, *Response, error) {
+	apiEndpoint := "rest/api/2/project/search"
+	url, err := addOptions(apiEndpoint, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(ProjectSearchResult)
+	resp, err := s.client.Do(req, result)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return result, resp, nil
+}
+
+// SearchPages calls f for every project matching the options, fetching one page of projects after the other.
+// Iteration stops at the first error returned by f.
+func (s *ProjectService) SearchPages(ctx context.Context, options *ProjectSearchOptions, f func(Project) error) error {
+	opts := ProjectSearchOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.MaxResults == 0 {
+		opts.MaxResults = 50
+	}
+
+	for {
+		result, _, err := s.Search(ctx, &opts)
+		if err != nil {
+			return err
+		}
+		for _, project := range result.Values {
+			if err := f(project); err != nil {
+				return err
+			}
+		}
+
+		opts.StartAt += len(result.Values)
+		if len(result.Values) == 0 || result.IsLast || opts.StartAt >= result.Total {
+			return nil
+		}
+	}
+}
+
+// GetCategories returns all project categories.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-categories/#api-rest-api-2-projectcategory-get
+func (s *ProjectService) GetCategories(ctx context.Context) ([]ProjectCategory, *Response, error) {
+	apiEndpoint := "rest/api/2/projectCategory"
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var categories []ProjectCategory
+	resp, err := s.client.Do(req, &categories)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return categories, resp, nil
+}
+
+// GetCategory returns a project category.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-categories/#api-rest-api-2-projectcategory-id-get
+func (s *ProjectService) GetCategory(ctx context.Context, categoryID string) (*ProjectCategory, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/projectCategory/%s", categoryID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	category := new(ProjectCategory)
+	resp, err := s.client.Do(req, category)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return category, resp, nil
+}
+
+// projectCategoryRequest is the body to create or update a project category.
+// ProjectCategory always sends its ID and self link, which Jira rejects.
+type projectCategoryRequest struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// CreateCategory creates a project category from the name and description of category.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-categories/#api-rest-api-2-projectcategory-post
+func (s *ProjectService) CreateCategory(ctx context.Context, category *ProjectCategory) (*ProjectCategory, *Response, error) {
+	apiEndpoint := "rest/api/2/projectCategory"
+	body := &projectCategoryRequest{Name: category.Name, Description: category.Description}
+	req, err := s.client.NewRequest(ctx, http.MethodPost, apiEndpoint, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	created := new(ProjectCategory)
+	resp, err := s.client.Do(req, created)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return created, resp, nil
+}
+
+// UpdateCategory changes the name and description of the category with the ID of category.
+// Empty values are left unchanged.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-categories/#api-rest-api-2-projectcategory-id-put
+func (s *ProjectService) UpdateCategory(ctx context.Context, category *ProjectCategory) (*ProjectCategory, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/projectCategory/%s", category.ID)
+	body := &projectCategoryRequest{Name: category.Name, Description: category.Description}
+	req, err := s.client.NewRequest(ctx, http.MethodPut, apiEndpoint, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated := new(ProjectCategory)
+	resp, err := s.client.Do(req, updated)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return updated, resp, nil
+}
+
+// DeleteCategory deletes a project category. Projects of the category are left without category.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-categories/#api-rest-api-2-projectcategory-id-delete
+// Caller must close resp.Body
+func (s *ProjectService) DeleteCategory(ctx context.Context, categoryID string) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/projectCategory/%s", categoryID)
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, apiEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, NewJiraError(resp, err)
+	}
+	return resp, nil
+}
+
+// ProjectType is a type of project, like software or business.
+type ProjectType struct {
+	Key                string `json:"key" structs:"key"`
+	FormattedKey       string `json:"formattedKey" structs:"formattedKey"`
+	DescriptionI18nKey string `json:"descriptionI18nKey" structs:"descriptionI18nKey"`
+	// Icon is the base64 encoded SVG icon.
+	Icon  string `json:"icon" structs:"icon"`
+	Color string `json:"color" structs:"color"`
+}
+
+// GetProjectTypes returns all project types, whether or not the instance has a license for them.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-types/#api-rest-api-2-project-type-get
+func (s *ProjectService) GetProjectTypes(ctx context.Context) ([]ProjectType, *Response, error) {
+	apiEndpoint := "rest/api/2/project/type"
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var types []ProjectType
+	resp, err := s.client.Do(req, &types)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return types, resp, nil
+}
+
+// GetProjectType returns a project type by key, e.g. ProjectTypeSoftware.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-types/#api-rest-api-2-project-type-projecttypekey-get
+func (s *ProjectService) GetProjectType(ctx context.Context, key string) (*ProjectType, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/project/type/%s", key)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	projectType := new(ProjectType)
+	resp, err := s.client.Do(req, projectType)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return projectType, resp, nil
+}
+
+// Avatar is an avatar of a project, user or issue type.
+type Avatar struct {
+	ID             string            `json:"id" structs:"id"`
+	Owner          string            `json:"owner,omitempty" structs:"owner,omitempty"`
+	IsSystemAvatar bool              `json:"isSystemAvatar" structs:"isSystemAvatar"`
+	IsSelected     bool              `json:"isSelected" structs:"isSelected"`
+	IsDeletable    bool              `json:"isDeletable" structs:"isDeletable"`
+	FileName       string            `json:"fileName,omitempty" structs:"fileName,omitempty"`
+	URLs           map[string]string `json:"urls,omitempty" structs:"urls,omitempty"`
+}
+
+// ProjectAvatars are the avatars a project can use.
+type ProjectAvatars struct {
+	System []Avatar `json:"system" structs:"system"`
+	Custom []Avatar `json:"custom" structs:"custom"`
+}
+
+// GetAvatars returns the system avatars and the avatars uploaded for the project.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-avatars/#api-rest-api-2-project-projectidorkey-avatars-get
+func (s *ProjectService) GetAvatars(ctx context.Context, projectID string) (*ProjectAvatars, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/project/%s/avatars", projectID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	avatars := new(ProjectAvatars)
+	resp, err := s.client.Do(req, avatars)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return avatars, resp, nil
+}
+
+// UploadAvatarOptions specifies the optional parameters to ProjectService.UploadAvatar
+type UploadAvatarOptions struct {
+	// X and Y are the top left corner of the cropped square.
+	X int `url:"x,omitempty"`
+	Y int `url:"y,omitempty"`
+	// Size is the length of the side of the cropped square. Default: the smaller side of the image.
+	Size int `url:"size,omitempty"`
+}
+
+// UploadAvatar uploads an image as avatar of the project. contentType is the media type of the image,
+// e.g. "image/png". The avatar is not selected; pass its ID to SetAvatar to use it.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-avatars/#api-rest-api-2-project-projectidorkey-avatar2-post
+func (s *ProjectService) UploadAvatar(ctx context.Context, projectID string, image io.Reader, contentType string, options *UploadAvatarOptions) (*Avatar, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/project/%s/avatar2", projectID)
+	url, err := addOptions(apiEndpoint, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRawRequest(ctx, http.MethodPost, url, image)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Atlassian-Token", "nocheck")
+
+	avatar := new(Avatar)
+	resp, err := s.client.Do(req, avatar)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return avatar, resp, nil
+}
+
+// SetAvatar selects the avatar of the project.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-avatars/#api-rest-api-2-project-projectidorkey-avatar-put
+func (s *ProjectService) SetAvatar(ctx context.Context, projectID, avatarID string) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/project/%s/avatar", projectID)
+	req, err := s.client.NewRequest(ctx, http.MethodPut, apiEndpoint, &Avatar{ID: avatarID})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, NewJiraError(resp, err)
+	}
+	return resp, nil
+}
+
+// DeleteAvatar deletes an uploaded avatar of the project. System avatars cannot be deleted.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-avatars/#api-rest-api-2-project-projectidorkey-avatar-id-delete
+// Caller must close resp.Body
+func (s *ProjectService) DeleteAvatar(ctx context.Context, projectID, avatarID string) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/project/%s/avatar/%s", projectID, avatarID)
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, apiEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, NewJiraError(resp, err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,288 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestProjectService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/project", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]interface{}{
+			"key": "OPS", "name": "Operations", "projectTypeKey": "software",
+			"projectTemplateKey": ProjectTemplateKanban, "leadAccountId": "abc", "permissionScheme": float64(10100),
+		}
+		if !reflect.DeepEqual(body, want) {
+			t.Errorf("Expected body %v, got %v", want, body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"self":"https://jira.example.com/rest/api/2/project/10010","id":10010,"key":"OPS"}`)
+	})
+
+	created, _, err := testClient.Project.Create(context.Background(), &ProjectDetails{
+		Key:                "OPS",
+		Name:               "Operations",
+		ProjectTypeKey:     ProjectTypeSoftware,
+		ProjectTemplateKey: ProjectTemplateKanban,
+		LeadAccountID:      "abc",
+		PermissionScheme:   10100,
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if created.ID != 10010 || created.Key != "OPS" {
+		t.Errorf("Unexpected project %+v", created)
+	}
+}
+
+func TestProjectService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/project/OPS", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		b, _ := io.ReadAll(r.Body)
+		if strings.TrimSpace(string(b)) != `{"name":"Ops","categoryId":10000}` {
+			t.Errorf("Unexpected body %s", b)
+		}
+		fmt.Fprint(w, `{"id":"10010","key":"OPS","name":"Ops","projectCategory":{"id":"10000","name":"Internal"}}`)
+	})
+
+	project, _, err := testClient.Project.Update(context.Background(), "OPS", &ProjectDetails{Name: "Ops", CategoryID: 10000})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if project.Name != "Ops" || project.ProjectCategory.Name != "Internal" {
+		t.Errorf("Unexpected project %+v", project)
+	}
+}
+
+func TestProjectService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/project/OPS", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := testClient.Project.Delete(context.Background(), "OPS"); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestProjectService_Archive(t *testing.T) {
+	for _, tt := range []struct {
+		deploymentType string
+		method         string
+	}{
+		{DeploymentTypeCloud, http.MethodPost},
+		{DeploymentTypeServer, http.MethodPut},
+	} {
+		setup()
+		handleServerInfo(t, tt.deploymentType)
+		var methods []string
+		testMux.HandleFunc("/rest/api/2/project/OPS/archive", func(w http.ResponseWriter, r *http.Request) {
+			methods = append(methods, r.Method)
+			w.WriteHeader(http.StatusNoContent)
+		})
+		testMux.HandleFunc("/rest/api/2/project/OPS/restore", func(w http.ResponseWriter, r *http.Request) {
+			methods = append(methods, r.Method)
+			fmt.Fprint(w, `{"key":"OPS"}`)
+		})
+
+		if _, err := testClient.Project.Archive(context.Background(), "OPS"); err != nil {
+			t.Errorf("%s: error given: %s", tt.deploymentType, err)
+		}
+		if _, err := testClient.Project.Restore(context.Background(), "OPS"); err != nil {
+			t.Errorf("%s: error given: %s", tt.deploymentType, err)
+		}
+		if want := []string{tt.method, tt.method}; !reflect.DeepEqual(methods, want) {
+			t.Errorf("%s: expected methods %v, got %v", tt.deploymentType, want, methods)
+		}
+		teardown()
+	}
+}
+
+func TestProjectService_SearchPages(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/project/search", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		q := r.URL.Query()
+		if q.Get("expand") != "lead,issueTypes" || q.Get("categoryId") != "10000" || !reflect.DeepEqual(q["status"], []string{"live", "archived"}) {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		switch q.Get("startAt") {
+		case "":
+			fmt.Fprint(w, `{"startAt":0,"maxResults":2,"total":3,"isLast":false,"values":[{"key":"A"},{"key":"B"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"startAt":2,"maxResults":2,"total":3,"isLast":true,"values":[{"key":"C","lead":{"accountId":"abc"}}]}`)
+		default:
+			t.Errorf("Unexpected startAt %s", q.Get("startAt"))
+		}
+	})
+
+	var keys []string
+	err := testClient.Project.SearchPages(context.Background(), &ProjectSearchOptions{
+		MaxResults: 2,
+		CategoryID: 10000,
+		Status:     []string{"live", "archived"},
+		Expand:     "lead,issueTypes",
+	}, func(p Project) error {
+		keys = append(keys, p.Key)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if want := []string{"A", "B", "C"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Expected projects %v, got %v", want, keys)
+	}
+
+	_, resp, err := testClient.Project.Search(context.Background(), &ProjectSearchOptions{
+		MaxResults: 2, CategoryID: 10000, Status: []string{"live", "archived"}, Expand: "lead,issueTypes",
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if resp.Total != 3 || resp.MaxResults != 2 {
+		t.Errorf("Expected paging values in the response, got %+v", resp)
+	}
+}
+
+func TestProjectService_Categories(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/projectCategory", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `[{"id":"10000","name":"Internal","description":"Internal projects"}]`)
+		case http.MethodPost:
+			b, _ := io.ReadAll(r.Body)
+			if strings.TrimSpace(string(b)) != `{"name":"External"}` {
+				t.Errorf("Unexpected body %s", b)
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":"10001","name":"External"}`)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+	testMux.HandleFunc("/rest/api/2/projectCategory/10001", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"id":"10001","name":"External"}`)
+		case http.MethodPut:
+			b, _ := io.ReadAll(r.Body)
+			if strings.TrimSpace(string(b)) != `{"description":"Customer projects"}` {
+				t.Errorf("Unexpected body %s", b)
+			}
+			fmt.Fprint(w, `{"id":"10001","name":"External","description":"Customer projects"}`)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	ctx := context.Background()
+	categories, _, err := testClient.Project.GetCategories(ctx)
+	if err != nil || len(categories) != 1 || categories[0].Name != "Internal" {
+		t.Errorf("Unexpected categories %+v, %v", categories, err)
+	}
+	created, _, err := testClient.Project.CreateCategory(ctx, &ProjectCategory{Name: "External"})
+	if err != nil || created.ID != "10001" {
+		t.Errorf("Unexpected category %+v, %v", created, err)
+	}
+	category, _, err := testClient.Project.GetCategory(ctx, "10001")
+	if err != nil || category.Name != "External" {
+		t.Errorf("Unexpected category %+v, %v", category, err)
+	}
+	updated, _, err := testClient.Project.UpdateCategory(ctx, &ProjectCategory{ID: "10001", Description: "Customer projects"})
+	if err != nil || updated.Description != "Customer projects" {
+		t.Errorf("Unexpected category %+v, %v", updated, err)
+	}
+	if _, err := testClient.Project.DeleteCategory(ctx, "10001"); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestProjectService_GetProjectTypes(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/project/type", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[{"key":"software","formattedKey":"Software","color":"#F5A623"},{"key":"business","formattedKey":"Business"}]`)
+	})
+	testMux.HandleFunc("/rest/api/2/project/type/software", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"key":"software","formattedKey":"Software"}`)
+	})
+
+	types, _, err := testClient.Project.GetProjectTypes(context.Background())
+	if err != nil || len(types) != 2 || types[0].FormattedKey != "Software" {
+		t.Errorf("Unexpected project types %+v, %v", types, err)
+	}
+	projectType, _, err := testClient.Project.GetProjectType(context.Background(), ProjectTypeSoftware)
+	if err != nil || projectType.Key != "software" {
+		t.Errorf("Unexpected project type %+v, %v", projectType, err)
+	}
+}
+
+func TestProjectService_Avatars(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/project/OPS/avatars", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"system":[{"id":"1","isSystemAvatar":true}],"custom":[{"id":"10010","isSelected":true,"isDeletable":true}]}`)
+	})
+	testMux.HandleFunc("/rest/api/2/project/OPS/avatar2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testRequestURL(t, r, "/rest/api/2/project/OPS/avatar2?size=64")
+		if r.Header.Get("Content-Type") != "image/png" || r.Header.Get("X-Atlassian-Token") != "nocheck" {
+			t.Errorf("Unexpected headers %v", r.Header)
+		}
+		if b, _ := io.ReadAll(r.Body); string(b) != "png" {
+			t.Errorf("Unexpected image %q", b)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"10011","isDeletable":true}`)
+	})
+	testMux.HandleFunc("/rest/api/2/project/OPS/avatar", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		b, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(b), `"id":"10011"`) {
+			t.Errorf("Unexpected body %s", b)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	testMux.HandleFunc("/rest/api/2/project/OPS/avatar/10010", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	avatars, _, err := testClient.Project.GetAvatars(ctx, "OPS")
+	if err != nil || len(avatars.System) != 1 || !avatars.Custom[0].IsSelected {
+		t.Errorf("Unexpected avatars %+v, %v", avatars, err)
+	}
+	avatar, _, err := testClient.Project.UploadAvatar(ctx, "OPS", strings.NewReader("png"), "image/png", &UploadAvatarOptions{Size: 64})
+	if err != nil || avatar.ID != "10011" {
+		t.Fatalf("Unexpected avatar %+v, %v", avatar, err)
+	}
+	if _, err := testClient.Project.SetAvatar(ctx, "OPS", avatar.ID); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if _, err := testClient.Project.DeleteAvatar(ctx, "OPS", "10010"); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
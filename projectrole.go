@@ -0,0 +1,475 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Actor types of Actor.Type
+const (
+	RoleActorUser  = "atlassian-user-role-actor"
+	RoleActorGroup = "atlassian-group-role-actor"
+)
+
+// userID returns the identifier of the user actor on the instance: the account ID on Jira Cloud
+// and the user name on Jira Server / Data Center.
+func (a *Actor) userID() string {
+	if a.ActorUser != nil && a.ActorUser.AccountID != "" {
+		return a.ActorUser.AccountID
+	}
+	return a.Name
+}
+
+// groupName returns the name of the group actor
+func (a *Actor) groupName() string {
+	if a.ActorGroup != nil && a.ActorGroup.Name != "" {
+		return a.ActorGroup.Name
+	}
+	return a.Name
+}
+
+// GetProjectRoles returns the roles of a project without their actors.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-roles/#api-rest-api-2-project-projectidorkey-role-get
+func (s *RoleService) GetProjectRoles(ctx context.Context, projectID string) ([]Role, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/project/%s/role", projectID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Jira returns the URLs of the roles by name, which end with the role ID
+	urls := map[string]string{}
+	resp, err := s.client.Do(req, &urls)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+
+	roles := make([]Role, 0, len(urls))
+	for name, self := range urls {
+		id, err := strconv.Atoi(path.Base(self))
+		if err != nil {
+			return nil, resp, fmt.Errorf("unexpected URL %q of role %q", self, name)
+		}
+		roles = append(roles, Role{Self: self, Name: name, ID: id})
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+	return roles, resp, nil
+}
+
+// GetProjectRole returns a role of a project with its actors.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-roles/#api-rest-api-2-project-projectidorkey-role-id-get
+func (s *RoleService) GetProjectRole(ctx context.Context, projectID string, roleID int) (*Role, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/project/%s/role/%d", projectID, roleID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	role := new(Role)
+	resp, err := s.client.Do(req, role)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return role, resp, nil
+}
+
+// roleActors is the body to add actors to a role
+type roleActors struct {
+	User  []string `json:"user,omitempty"`
+	Group []string `json:"group,omitempty"`
+}
+
+// userIDs returns the identifiers of the users on the instance, see IssueService.userParam
+func (s *RoleService) userIDs(ctx context.Context, users []*User) ([]string, error) {
+	ids := make([]string, len(users))
+	for i, user := range users {
+		_, id, err := (*IssueService)(s).userParam(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// AddProjectRoleUsers adds users as actors to a role of a project and returns the role with its actors.
+// The users are identified by account ID on Jira Cloud and by name on Jira Server / Data Center.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-role-actors/#api-rest-api-2-project-projectidorkey-role-id-post
+func (s *RoleService) AddProjectRoleUsers(ctx context.Context, projectID string, roleID int, users []*User) (*Role, *Response, error) {
+	ids, err := s.userIDs(ctx, users)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.addProjectRoleActors(ctx, projectID, roleID, &roleActors{User: ids})
+}
+
+// AddProjectRoleGroups adds groups as actors to a role of a project and returns the role with its actors.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-role-actors/#api-rest-api-2-project-projectidorkey-role-id-post
+func (s *RoleService) AddProjectRoleGroups(ctx context.Context, projectID string, roleID int, groups []string) (*Role, *Response, error) {
+	return s.addProjectRoleActors(ctx, projectID, roleID, &roleActors{Group: groups})
+}
+
+func (s *RoleService) addProjectRoleActors(ctx context.Context, projectID string, roleID int, actors *roleActors) (*Role, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/project/%s/role/%d", projectID, roleID)
+	req, err := s.client.NewRequest(ctx, http.MethodPost, apiEndpoint, actors)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	role := new(Role)
+	resp, err := s.client.Do(req, role)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return role, resp, nil
+}
+
+// RemoveProjectRoleUser removes a user actor from a role of a project.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-role-actors/#api-rest-api-2-project-projectidorkey-role-id-delete
+// Caller must close resp.Body
+func (s *RoleService) RemoveProjectRoleUser(ctx context.Context, projectID string, roleID int, user *User) (*Response, error) {
+	ids, err := s.userIDs(ctx, []*User{user})
+	if err != nil {
+		return nil, err
+	}
+	apiEndpoint := fmt.Sprintf("rest/api/2/project/%s/role/%d?user=%s", projectID, roleID, url.QueryEscape(ids[0]))
+	return s.deleteActor(ctx, apiEndpoint)
+}
+
+// RemoveProjectRoleGroup removes a group actor from a role of a project.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-role-actors/#api-rest-api-2-project-projectidorkey-role-id-delete
+// Caller must close resp.Body
+func (s *RoleService) RemoveProjectRoleGroup(ctx context.Context, projectID string, roleID int, group string) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/project/%s/role/%d?group=%s", projectID, roleID, url.QueryEscape(group))
+	return s.deleteActor(ctx, apiEndpoint)
+}
+
+// deleteActor removes the actor given in the query of apiEndpoint
+// Caller must close resp.Body
+func (s *RoleService) deleteActor(ctx context.Context, apiEndpoint string) (*Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, apiEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, NewJiraError(resp, err)
+	}
+	return resp, nil
+}
+
+// SetProjectRoleActors replaces all actors of a role of a project with the users and groups
+// and returns the role with its actors.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-role-actors/#api-rest-api-2-project-projectidorkey-role-id-put
+func (s *RoleService) SetProjectRoleActors(ctx context.Context, projectID string, roleID int, users []*User, groups []string) (*Role, *Response, error) {
+	ids, err := s.userIDs(ctx, users)
+	if err != nil {
+		return nil, nil, err
+	}
+	body := map[string]interface{}{
+		"id": roleID,
+		"categorisedActors": map[string][]string{
+			RoleActorUser:  append([]string{}, ids...),
+			RoleActorGroup: append([]string{}, groups...),
+		},
+	}
+
+	apiEndpoint := fmt.Sprintf("rest/api/2/project/%s/role/%d", projectID, roleID)
+	req, err := s.client.NewRequest(ctx, http.MethodPut, apiEndpoint, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	role := new(Role)
+	resp, err := s.client.Do(req, role)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return role, resp, nil
+}
+
+// GetDefaultActors returns the default actors of a role, which are added to the role of new projects.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-role-actors/#api-rest-api-2-role-id-actors-get
+func (s *RoleService) GetDefaultActors(ctx context.Context, roleID int) (*Role, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/role/%d/actors", roleID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	role := new(Role)
+	resp, err := s.client.Do(req, role)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return role, resp, nil
+}
+
+// AddDefaultActors adds users and groups to the default actors of a role and returns the role with its default actors.
+// The users are identified by account ID on Jira Cloud and by name on Jira Server / Data Center.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-role-actors/#api-rest-api-2-role-id-actors-post
+func (s *RoleService) AddDefaultActors(ctx context.Context, roleID int, users []*User, groups []string) (*Role, *Response, error) {
+	ids, err := s.userIDs(ctx, users)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiEndpoint := fmt.Sprintf("rest/api/2/role/%d/actors", roleID)
+	req, err := s.client.NewRequest(ctx, http.MethodPost, apiEndpoint, &roleActors{User: ids, Group: groups})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	role := new(Role)
+	resp, err := s.client.Do(req, role)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return role, resp, nil
+}
+
+// RemoveDefaultUser removes a user from the default actors of a role.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-role-actors/#api-rest-api-2-role-id-actors-delete
+// Caller must close resp.Body
+func (s *RoleService) RemoveDefaultUser(ctx context.Context, roleID int, user *User) (*Response, error) {
+	ids, err := s.userIDs(ctx, []*User{user})
+	if err != nil {
+		return nil, err
+	}
+	return s.deleteActor(ctx, fmt.Sprintf("rest/api/2/role/%d/actors?user=%s", roleID, url.QueryEscape(ids[0])))
+}
+
+// RemoveDefaultGroup removes a group from the default actors of a role.
+//
+// Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-project-role-actors/#api-rest-api-2-role-id-actors-delete
+// Caller must close resp.Body
+func (s *RoleService) RemoveDefaultGroup(ctx context.Context, roleID int, group string) (*Response, error) {
+	return s.deleteActor(ctx, fmt.Sprintf("rest/api/2/role/%d/actors?group=%s", roleID, url.QueryEscape(group)))
+}
+
+// RoleMembership is the desired membership of a role in several projects.
+type RoleMembership struct {
+	Projects []string `json:"projects" yaml:"projects"`
+	// Role is the name or ID of the role.
+	Role string `json:"role" yaml:"role"`
+	// Users are account IDs on Jira Cloud and user names on Jira Server / Data Center.
+	Users  []string `json:"users,omitempty" yaml:"users,omitempty"`
+	Groups []string `json:"groups,omitempty" yaml:"groups,omitempty"`
+}
+
+// RoleMembershipConfig is a file of desired role memberships, see RoleService.ReconcileProjectRoles.
+type RoleMembershipConfig struct {
+	Roles []RoleMembership `json:"roles" yaml:"roles"`
+}
+
+// ParseRoleMembershipConfig parses a role membership config in YAML or JSON, like
+//
+//	roles:
+//	  - projects: [OPS, WEB]
+//	    role: Developers
+//	    groups: [developers]
+//	    users: [5b10ac8d82e05b22cc7d4ef5]
+func ParseRoleMembershipConfig(document []byte) (*RoleMembershipConfig, error) {
+	config := new(RoleMembershipConfig)
+	// YAML is a superset of JSON, so a single decoder reads both
+	if err := yaml.Unmarshal(document, config); err != nil {
+		return nil, fmt.Errorf("decoding role membership config: %w", err)
+	}
+	return config, nil
+}
+
+// ReconcileRolesOptions specifies the optional parameters to RoleService.ReconcileProjectRoles
+type ReconcileRolesOptions struct {
+	// DryRun only reports the changes without applying them.
+	DryRun bool
+	// KeepUnlisted keeps actors which are not listed in the config instead of removing them.
+	KeepUnlisted bool
+}
+
+// RoleChange is a change of a role actor made by RoleService.ReconcileProjectRoles.
+type RoleChange struct {
+	Project string
+	Role    string
+	// ActorType is RoleActorUser or RoleActorGroup.
+	ActorType string
+	// Actor is the account ID or user name of a user, or the name of a group.
+	Actor string
+	// Removed is true if the actor was removed, false if it was added.
+	Removed bool
+}
+
+func (c RoleChange) String() string {
+	kind := "user"
+	if c.ActorType == RoleActorGroup {
+		kind = "group"
+	}
+	if c.Removed {
+		return fmt.Sprintf("%s %s: remove %s %s", c.Project, c.Role, kind, c.Actor)
+	}
+	return fmt.Sprintf("%s %s: add %s %s", c.Project, c.Role, kind, c.Actor)
+}
+
+// ReconcileProjectRoles makes the actors of the roles in the projects of the config match the config.
+// Missing users and groups are added and, unless options.KeepUnlisted is set, unlisted ones are removed.
+// It returns the changes made, or the changes to make if options.DryRun is set. On error, the changes
+// made so far are returned with the error.
+func (s *RoleService) ReconcileProjectRoles(ctx context.Context, config *RoleMembershipConfig, options *ReconcileRolesOptions) ([]RoleChange, error) {
+	opts := ReconcileRolesOptions{}
+	if options != nil {
+		opts = *options
+	}
+	cloud, err := s.client.Configuration.IsCloud(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []RoleChange
+	for _, membership := range config.Roles {
+		for _, project := range membership.Projects {
+			roleChanges, err := s.reconcileProjectRole(ctx, project, &membership, &opts, cloud)
+			changes = append(changes, roleChanges...)
+			if err != nil {
+				return changes, fmt.Errorf("reconciling role %q of %s: %w", membership.Role, project, err)
+			}
+		}
+	}
+	return changes, nil
+}
+
+func (s *RoleService) reconcileProjectRole(ctx context.Context, project string, membership *RoleMembership, opts *ReconcileRolesOptions, cloud bool) ([]RoleChange, error) {
+	roleID, err := s.projectRoleID(ctx, project, membership.Role)
+	if err != nil {
+		return nil, err
+	}
+	role, _, err := s.GetProjectRole(ctx, project, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// current actors by roleActorKey
+	currentUsers, currentGroups := map[string]string{}, map[string]string{}
+	for _, actor := range role.Actors {
+		switch actor.Type {
+		case RoleActorUser:
+			currentUsers[roleActorKey(RoleActorUser, actor.userID(), cloud)] = actor.userID()
+		case RoleActorGroup:
+			currentGroups[roleActorKey(RoleActorGroup, actor.groupName(), cloud)] = actor.groupName()
+		}
+	}
+
+	var changes []RoleChange
+	var addUsers []*User
+	var addGroups []string
+	for _, id := range membership.Users {
+		if _, ok := currentUsers[roleActorKey(RoleActorUser, id, cloud)]; !ok {
+			addUsers = append(addUsers, roleUser(id, cloud))
+			changes = append(changes, RoleChange{Project: project, Role: role.Name, ActorType: RoleActorUser, Actor: id})
+		}
+	}
+	for _, group := range membership.Groups {
+		if _, ok := currentGroups[roleActorKey(RoleActorGroup, group, cloud)]; !ok {
+			addGroups = append(addGroups, group)
+			changes = append(changes, RoleChange{Project: project, Role: role.Name, ActorType: RoleActorGroup, Actor: group})
+		}
+	}
+	var removals []RoleChange
+	if !opts.KeepUnlisted {
+		removals = unlistedActors(project, role.Name, RoleActorUser, currentUsers, membership.Users, cloud)
+		removals = append(removals, unlistedActors(project, role.Name, RoleActorGroup, currentGroups, membership.Groups, cloud)...)
+	}
+	if opts.DryRun {
+		return append(changes, removals...), nil
+	}
+
+	if len(addUsers) > 0 {
+		if _, _, err := s.AddProjectRoleUsers(ctx, project, roleID, addUsers); err != nil {
+			return nil, err
+		}
+	}
+	if len(addGroups) > 0 {
+		if _, _, err := s.AddProjectRoleGroups(ctx, project, roleID, addGroups); err != nil {
+			return changes[:len(addUsers)], err
+		}
+	}
+	for _, removal := range removals {
+		var resp *Response
+		if removal.ActorType == RoleActorUser {
+			resp, err = s.RemoveProjectRoleUser(ctx, project, roleID, roleUser(removal.Actor, cloud))
+		} else {
+			resp, err = s.RemoveProjectRoleGroup(ctx, project, roleID, removal.Actor)
+		}
+		if err != nil {
+			return changes, err
+		}
+		resp.Body.Close()
+		changes = append(changes, removal)
+	}
+	return changes, nil
+}
+
+// projectRoleID returns the ID of the role of the project with the ID or name
+func (s *RoleService) projectRoleID(ctx context.Context, project, role string) (int, error) {
+	roles, _, err := s.GetProjectRoles(ctx, project)
+	if err != nil {
+		return 0, err
+	}
+	for _, r := range roles {
+		if strconv.Itoa(r.ID) == role || strings.EqualFold(r.Name, role) {
+			return r.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("project %s has no role %q", project, role)
+}
+
+// roleUser returns the user with the identifier of a role membership config
+func roleUser(id string, cloud bool) *User {
+	if cloud {
+		return &User{AccountID: id}
+	}
+	return &User{Name: id}
+}
+
+// roleActorKey returns the key to compare role actors by.
+// Jira compares group names and user names case-insensitively; account IDs are compared as they are.
+func roleActorKey(actorType, id string, cloud bool) string {
+	if actorType == RoleActorUser && cloud {
+		return id
+	}
+	return strings.ToLower(id)
+}
+
+// unlistedActors returns the removal of the current actors which are not listed, sorted by actor.
+// current maps the roleActorKey of the current actors to their names.
+func unlistedActors(project, role, actorType string, current map[string]string, listed []string, cloud bool) []RoleChange {
+	keep := map[string]bool{}
+	for _, id := range listed {
+		keep[roleActorKey(actorType, id, cloud)] = true
+	}
+	var removals []RoleChange
+	for key, id := range current {
+		if !keep[key] {
+			removals = append(removals, RoleChange{Project: project, Role: role, ActorType: actorType, Actor: id, Removed: true})
+		}
+	}
+	sort.Slice(removals, func(i, j int) bool { return removals[i].Actor < removals[j].Actor })
+	return removals
+}
@@ -0,0 +1,203 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRoleService_GetProjectRoles(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/project/OPS/role", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"Developers":"https://jira.example.com/rest/api/2/project/OPS/role/10002",
+			"Administrators":"https://jira.example.com/rest/api/2/project/OPS/role/10001"}`)
+	})
+
+	roles, _, err := testClient.Role.GetProjectRoles(context.Background(), "OPS")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(roles) != 2 || roles[0].Name != "Administrators" || roles[0].ID != 10001 || roles[1].ID != 10002 {
+		t.Errorf("Unexpected roles %+v", roles)
+	}
+}
+
+func TestRoleService_ProjectRoleActors(t *testing.T) {
+	setup()
+	defer teardown()
+	handleServerInfo(t, DeploymentTypeCloud)
+
+	var requests []string
+	testMux.HandleFunc("/rest/api/2/project/OPS/role/10002", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		requests = append(requests, strings.TrimSpace(r.Method+" "+r.URL.RawQuery+" "+string(b)))
+		fmt.Fprint(w, `{"id":10002,"name":"Developers","actors":[
+			{"id":1,"type":"atlassian-user-role-actor","displayName":"Fred","actorUser":{"accountId":"abc"}},
+			{"id":2,"type":"atlassian-group-role-actor","name":"developers","actorGroup":{"name":"developers","displayName":"developers"}}]}`)
+	})
+
+	ctx := context.Background()
+	role, _, err := testClient.Role.GetProjectRole(ctx, "OPS", 10002)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(role.Actors) != 2 || role.Actors[0].ActorUser.AccountID != "abc" || role.Actors[1].ActorGroup.Name != "developers" {
+		t.Errorf("Unexpected role %+v", role)
+	}
+	if _, _, err := testClient.Role.AddProjectRoleUsers(ctx, "OPS", 10002, []*User{{AccountID: "abc"}}); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if _, _, err := testClient.Role.AddProjectRoleGroups(ctx, "OPS", 10002, []string{"qa"}); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if _, err := testClient.Role.RemoveProjectRoleUser(ctx, "OPS", 10002, &User{AccountID: "abc"}); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if _, err := testClient.Role.RemoveProjectRoleGroup(ctx, "OPS", 10002, "jira users"); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if _, _, err := testClient.Role.SetProjectRoleActors(ctx, "OPS", 10002, nil, []string{"developers"}); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if _, _, err := testClient.Role.AddProjectRoleUsers(ctx, "OPS", 10002, []*User{{Name: "fred"}}); err == nil {
+		t.Error("Expected an error for a user without account ID on Jira Cloud")
+	}
+
+	want := []string{
+		"GET",
+		`POST  {"user":["abc"]}`,
+		`POST  {"group":["qa"]}`,
+		"DELETE user=abc",
+		"DELETE group=jira+users",
+		`PUT  {"categorisedActors":{"atlassian-group-role-actor":["developers"],"atlassian-user-role-actor":[]},"id":10002}`,
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("Expected requests\n%q\ngot\n%q", want, requests)
+	}
+}
+
+func TestRoleService_DefaultActors(t *testing.T) {
+	setup()
+	defer teardown()
+	handleServerInfo(t, DeploymentTypeServer)
+
+	var requests []string
+	testMux.HandleFunc("/rest/api/2/role/10002/actors", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		requests = append(requests, strings.TrimSpace(r.Method+" "+r.URL.RawQuery+" "+string(b)))
+		fmt.Fprint(w, `{"actors":[{"id":1,"type":"atlassian-user-role-actor","name":"fred"}]}`)
+	})
+
+	ctx := context.Background()
+	role, _, err := testClient.Role.GetDefaultActors(ctx, 10002)
+	if err != nil || len(role.Actors) != 1 || role.Actors[0].Name != "fred" {
+		t.Errorf("Unexpected default actors %+v, %v", role, err)
+	}
+	if _, _, err := testClient.Role.AddDefaultActors(ctx, 10002, []*User{{Name: "fred"}}, []string{"developers"}); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if _, err := testClient.Role.RemoveDefaultUser(ctx, 10002, &User{Name: "fred"}); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if _, err := testClient.Role.RemoveDefaultGroup(ctx, 10002, "developers"); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+
+	want := []string{"GET", `POST  {"user":["fred"],"group":["developers"]}`, "DELETE user=fred", "DELETE group=developers"}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("Expected requests %q, got %q", want, requests)
+	}
+}
+
+const roleMembershipTestConfig = `
+roles:
+  - projects: [OPS, WEB]
+    role: developers
+    users: [fred]
+    groups: [developers]
+`
+
+func TestRoleService_ReconcileProjectRoles(t *testing.T) {
+	setup()
+	defer teardown()
+	handleServerInfo(t, DeploymentTypeServer)
+
+	actors := map[string]string{
+		"OPS": `[{"type":"atlassian-user-role-actor","name":"Fred"},{"type":"atlassian-group-role-actor","name":"Developers"}]`,
+		"WEB": `[{"type":"atlassian-user-role-actor","name":"bob"},{"type":"atlassian-group-role-actor","name":"web"}]`,
+	}
+	var writes []string
+	for project, projectActors := range actors {
+		project, projectActors := project, projectActors
+		testMux.HandleFunc("/rest/api/2/project/"+project+"/role", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"Developers":"https://jira.example.com/rest/api/2/project/%s/role/10002"}`, project)
+		})
+		testMux.HandleFunc("/rest/api/2/project/"+project+"/role/10002", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				var body roleActors
+				json.NewDecoder(r.Body).Decode(&body)
+				writes = append(writes, fmt.Sprintf("%s %s %s %v %v", project, r.Method, r.URL.RawQuery, body.User, body.Group))
+			}
+			fmt.Fprintf(w, `{"id":10002,"name":"Developers","actors":%s}`, projectActors)
+		})
+	}
+
+	config, err := ParseRoleMembershipConfig([]byte(roleMembershipTestConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := testClient.Role.ReconcileProjectRoles(context.Background(), config, &ReconcileRolesOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"WEB Developers: add user fred",
+		"WEB Developers: add group developers",
+		"WEB Developers: remove user bob",
+		"WEB Developers: remove group web",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected changes %q, got %q", want, got)
+	}
+	if len(writes) != 0 {
+		t.Errorf("Expected no writes in a dry run, got %q", writes)
+	}
+
+	if _, err := testClient.Role.ReconcileProjectRoles(context.Background(), config, nil); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	wantWrites := []string{
+		"WEB POST  [fred] []",
+		"WEB POST  [] [developers]",
+		"WEB DELETE user=bob [] []",
+		"WEB DELETE group=web [] []",
+	}
+	if !reflect.DeepEqual(writes, wantWrites) {
+		t.Errorf("Expected writes %q, got %q", wantWrites, writes)
+	}
+
+	writes = nil
+	if _, err := testClient.Role.ReconcileProjectRoles(context.Background(), config, &ReconcileRolesOptions{KeepUnlisted: true}); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(writes) != 2 {
+		t.Errorf("Expected only additions, got %q", writes)
+	}
+
+	config.Roles[0].Role = "Testers"
+	if _, err := testClient.Role.ReconcileProjectRoles(context.Background(), config, nil); err == nil || !strings.Contains(err.Error(), `project OPS has no role "Testers"`) {
+		t.Errorf("Expected an unknown role error, got %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// PropertyService handles the properties of Jira entities such as issues, projects, users and sprints.
+// Properties are JSON values stored under a key by apps and integrations.
+//
+// Jira API docs: https://developer.atlassian.com/server/jira/platform/entity-properties/
+type PropertyService service
+
+// PropertyEntity identifies an entity which holds properties.
+// Use the constructors like IssueEntity or SprintEntity to create one.
+type PropertyEntity struct {
+	// path is the endpoint listing the property keys of the entity
+	path string
+	// query identifies the entity for endpoints which take it as query parameter
+	query url.Values
+}
+
+func (e PropertyEntity) url(propertyKey string) string {
+	u := e.path
+	if propertyKey != "" {
+		u += "/" + url.PathEscape(propertyKey)
+	}
+	if len(e.query) > 0 {
+		u += "?" + e.query.Encode()
+	}
+	return u
+}
+
+// IssueEntity identifies the properties of an issue.
+func IssueEntity(issueIDOrKey string) PropertyEntity {
+	return PropertyEntity{path: fmt.Sprintf("rest/api/2/issue/%s/properties", issueIDOrKey)}
+}
+
+// ProjectEntity identifies the properties of a project.
+func ProjectEntity(projectIDOrKey string) PropertyEntity {
+	return PropertyEntity{path: fmt.Sprintf("rest/api/2/project/%s/properties", projectIDOrKey)}
+}
+
+// UserEntity identifies the properties of a user by user name, as on Jira Server / Data Center.
+func UserEntity(username string) PropertyEntity {
+	return PropertyEntity{path: "rest/api/2/user/properties", query: url.Values{"username": {username}}}
+}
+
+// UserAccountEntity identifies the properties of a user by account ID, as on Jira Cloud.
+func UserAccountEntity(accountID string) PropertyEntity {
+	return PropertyEntity{path: "rest/api/2/user/properties", query: url.Values{"accountId": {accountID}}}
+}
+
+// CommentEntity identifies the properties of a comment.
+func CommentEntity(commentID string) PropertyEntity {
+	return PropertyEntity{path: fmt.Sprintf("rest/api/2/comment/%s/properties", commentID)}
+}
+
+// WorklogEntity identifies the properties of a worklog of an issue.
+func WorklogEntity(issueIDOrKey, worklogID string) PropertyEntity {
+	return PropertyEntity{path: fmt.Sprintf("rest/api/2/issue/%s/worklog/%s/properties", issueIDOrKey, worklogID)}
+}
+
+// BoardEntity identifies the properties of an agile board.
+func BoardEntity(boardID int) PropertyEntity {
+	return PropertyEntity{path: fmt.Sprintf("rest/agile/1.0/board/%d/properties", boardID)}
+}
+
+// SprintEntity identifies the properties of a sprint.
+func SprintEntity(sprintID int) PropertyEntity {
+	return PropertyEntity{path: fmt.Sprintf("rest/agile/1.0/sprint/%d/properties", sprintID)}
+}
+
+// DashboardItemEntity identifies the properties of a gadget on a dashboard.
+func DashboardItemEntity(dashboardID, itemID string) PropertyEntity {
+	return PropertyEntity{path: fmt.Sprintf("rest/api/2/dashboard/%s/items/%s/properties", dashboardID, itemID)}
+}
+
+// OrganizationEntity identifies the properties of a Jira Service Management organization.
+func OrganizationEntity(organizationID int) PropertyEntity {
+	return PropertyEntity{path: fmt.Sprintf("rest/servicedeskapi/organization/%d/property", organizationID)}
+}
+
+// GetKeys returns the keys of all properties of the entity.
+func (s *PropertyService) GetKeys(ctx context.Context, entity PropertyEntity) (*PropertyKeys, *Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodGet, entity.url(""), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pk := new(PropertyKeys)
+	resp, err := s.client.Do(req, pk)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return pk, resp, nil
+}
+
+// Get returns the property with the given key of the entity.
+func (s *PropertyService) Get(ctx context.Context, entity PropertyEntity, propertyKey string) (*EntityProperty, *Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodGet, entity.url(propertyKey), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ep := new(EntityProperty)
+	resp, err := s.client.Do(req, ep)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return ep, resp, nil
+}
+
+// Decode fetches the property with the given key of the entity and decodes its value into v,
+// which must be a pointer, e.g. to a struct with json tags.
+func (s *PropertyService) Decode(ctx context.Context, entity PropertyEntity, propertyKey string, v interface{}) (*Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodGet, entity.url(propertyKey), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var property struct {
+		Key   string          `json:"key"`
+		Value json.RawMessage `json:"value"`
+	}
+	resp, err := s.client.Do(req, &property)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return resp, jerr
+	}
+
+	if err := json.Unmarshal(property.Value, v); err != nil {
+		return resp, fmt.Errorf("decoding property %q: %w", propertyKey, err)
+	}
+	return resp, nil
+}
+
+// Set sets the property with the given key of the entity to value, which must be serializable to JSON.
+// Caller must close resp.Body
+func (s *PropertyService) Set(ctx context.Context, entity PropertyEntity, propertyKey string, value interface{}) (*Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodPut, entity.url(propertyKey), value)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		err = NewJiraError(resp, err)
+	}
+	return resp, err
+}
+
+// Delete removes the property with the given key from the entity.
+// Caller must close resp.Body
+func (s *PropertyService) Delete(ctx context.Context, entity PropertyEntity, propertyKey string) (*Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, entity.url(propertyKey), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		err = NewJiraError(resp, err)
+	}
+	return resp, err
+}
+
+// SetIssuePropertyByJQL sets the property with the given key to value on every issue matching jql,
+// running at most concurrency requests at once (default: 4). It returns the number of updated issues.
+// Jira Server / Data Center has no bulk property endpoint, so every issue is updated with its own request.
+func (s *PropertyService) SetIssuePropertyByJQL(ctx context.Context, jql, propertyKey string, value interface{}, concurrency int) (int, error) {
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	var (
+		mu       sync.Mutex
+		updated  int
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	sem := make(chan struct{}, concurrency)
+
+	err := s.client.Issue.SearchPages(ctx, jql, &SearchOptions{MaxResults: 50, Fields: []string{"key"}}, func(issue Issue) error {
+		mu.Lock()
+		err := firstErr
+		mu.Unlock()
+		if err != nil {
+			return err
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(key string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			resp, err := s.Set(ctx, IssueEntity(key), propertyKey, value)
+			if resp != nil {
+				resp.Body.Close()
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("setting property %q of %s: %w", propertyKey, key, err)
+				}
+				return
+			}
+			updated++
+		}(issue.Key)
+		return nil
+	})
+	wg.Wait()
+
+	if err == nil {
+		err = firstErr
+	}
+	return updated, err
+}
@@ -0,0 +1,112 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestPropertyEntity_URL(t *testing.T) {
+	tests := []struct {
+		entity PropertyEntity
+		key    string
+		want   string
+	}{
+		{IssueEntity("TEST-1"), "", "rest/api/2/issue/TEST-1/properties"},
+		{ProjectEntity("TEST"), "config", "rest/api/2/project/TEST/properties/config"},
+		{UserEntity("fred"), "prefs", "rest/api/2/user/properties/prefs?username=fred"},
+		{UserAccountEntity("5b10"), "", "rest/api/2/user/properties?accountId=5b10"},
+		{CommentEntity("10001"), "a b", "rest/api/2/comment/10001/properties/a%20b"},
+		{WorklogEntity("TEST-1", "10100"), "k", "rest/api/2/issue/TEST-1/worklog/10100/properties/k"},
+		{BoardEntity(1), "k", "rest/agile/1.0/board/1/properties/k"},
+		{SprintEntity(2), "k", "rest/agile/1.0/sprint/2/properties/k"},
+		{DashboardItemEntity("10000", "20000"), "k", "rest/api/2/dashboard/10000/items/20000/properties/k"},
+		{OrganizationEntity(3), "k", "rest/servicedeskapi/organization/3/property/k"},
+	}
+	for _, tt := range tests {
+		if got := tt.entity.url(tt.key); got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, got)
+		}
+	}
+}
+
+func TestPropertyService(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/project/TEST/properties", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"keys":[{"key":"config"}]}`)
+	})
+	testMux.HandleFunc("/rest/api/2/project/TEST/properties/config", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"key":"config","value":{"enabled":true,"limit":3}}`)
+		case http.MethodPut:
+			var value map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&value); err != nil || value["limit"] != float64(5) {
+				t.Errorf("Unexpected value %v (%v)", value, err)
+			}
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	ctx := context.Background()
+	entity := ProjectEntity("TEST")
+
+	keys, _, err := testClient.Property.GetKeys(ctx, entity)
+	if err != nil || len(keys.Keys) != 1 || keys.Keys[0].Key != "config" {
+		t.Errorf("Unexpected keys %+v (%v)", keys, err)
+	}
+
+	var config struct {
+		Enabled bool `json:"enabled"`
+		Limit   int  `json:"limit"`
+	}
+	if _, err := testClient.Property.Decode(ctx, entity, "config", &config); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if !config.Enabled || config.Limit != 3 {
+		t.Errorf("Unexpected decoded value %+v", config)
+	}
+
+	config.Limit = 5
+	if _, err := testClient.Property.Set(ctx, entity, "config", config); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if _, err := testClient.Property.Delete(ctx, entity, "config"); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestPropertyService_SetIssuePropertyByJQL(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"startAt":0,"maxResults":50,"total":3,"issues":[{"key":"TEST-1"},{"key":"TEST-2"},{"key":"TEST-3"}]}`)
+	})
+	var mu sync.Mutex
+	set := map[string]bool{}
+	for _, key := range []string{"TEST-1", "TEST-2", "TEST-3"} {
+		key := key
+		testMux.HandleFunc("/rest/api/2/issue/"+key+"/properties/synced", func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodPut)
+			mu.Lock()
+			set[key] = true
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+		})
+	}
+
+	n, err := testClient.Property.SetIssuePropertyByJQL(context.Background(), "project = TEST", "synced", true, 2)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if n != 3 || len(set) != 3 {
+		t.Errorf("Expected 3 updated issues, got %d (%v)", n, set)
+	}
+}
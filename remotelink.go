@@ -0,0 +1,204 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GetRemoteLink gets a single remote issue link by linkID.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/issue-getRemoteIssueLinkById
+func (s *IssueService) GetRemoteLink(ctx context.Context, issueID string, linkID int) (*RemoteLink, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/remotelink/%d", issueID, linkID)
+	return s.getRemoteLink(ctx, apiEndpoint)
+}
+
+// GetRemoteLinkByGlobalID gets the remote issue link with the given global ID.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/issue-getRemoteIssueLinks
+func (s *IssueService) GetRemoteLinkByGlobalID(ctx context.Context, issueID, globalID string) (*RemoteLink, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/remotelink?globalId=%s", issueID, url.QueryEscape(globalID))
+	return s.getRemoteLink(ctx, apiEndpoint)
+}
+
+func (s *IssueService) getRemoteLink(ctx context.Context, apiEndpoint string) (*RemoteLink, *Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	remotelink := new(RemoteLink)
+	resp, err := s.client.Do(req, remotelink)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return remotelink, resp, nil
+}
+
+// DeleteRemoteLink deletes a remote issue link by linkID.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/issue-deleteRemoteIssueLinkById
+// Caller must close resp.Body
+func (s *IssueService) DeleteRemoteLink(ctx context.Context, issueID string, linkID int) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/remotelink/%d", issueID, linkID)
+	return s.deleteRemoteLink(ctx, apiEndpoint)
+}
+
+// DeleteRemoteLinkByGlobalID deletes the remote issue link with the given global ID.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/issue-deleteRemoteIssueLinkByGlobalId
+// Caller must close resp.Body
+func (s *IssueService) DeleteRemoteLinkByGlobalID(ctx context.Context, issueID, globalID string) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/remotelink?globalId=%s", issueID, url.QueryEscape(globalID))
+	return s.deleteRemoteLink(ctx, apiEndpoint)
+}
+
+func (s *IssueService) deleteRemoteLink(ctx context.Context, apiEndpoint string) (*Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, apiEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		err = NewJiraError(resp, err)
+	}
+	return resp, err
+}
+
+// UpsertRemoteLink creates the remote issue link or, if a link with the same global ID exists, updates it.
+// The returned link only holds the ID and Self of the created or updated link.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/issue-createOrUpdateRemoteIssueLink
+func (s *IssueService) UpsertRemoteLink(ctx context.Context, issueID string, remotelink *RemoteLink) (*RemoteLink, *Response, error) {
+	if remotelink.GlobalID == "" {
+		return nil, nil, fmt.Errorf("remote link %q has no global ID", remoteLinkTitle(remotelink))
+	}
+	return s.AddRemoteLink(ctx, issueID, remotelink)
+}
+
+func remoteLinkTitle(remotelink *RemoteLink) string {
+	if remotelink.Object == nil {
+		return ""
+	}
+	return remotelink.Object.Title
+}
+
+// RemoteLinkSyncResult lists the global IDs of the remote links changed by IssueService.SyncRemoteLinks.
+type RemoteLinkSyncResult struct {
+	Created   []string
+	Updated   []string
+	Unchanged []string
+	Deleted   []string
+}
+
+// SyncRemoteLinks makes the remote links of the issue match the desired links, keyed by GlobalID.
+// Desired links are created if missing and updated if they differ. Existing links whose global ID
+// starts with managedPrefix but are not desired are deleted; other links are left untouched.
+// An empty managedPrefix manages all links with a global ID. Every desired link must have a global ID.
+func (s *IssueService) SyncRemoteLinks(ctx context.Context, issueID string, desired []RemoteLink, managedPrefix string) (*RemoteLinkSyncResult, error) {
+	wanted := make(map[string]bool, len(desired))
+	for i := range desired {
+		if desired[i].GlobalID == "" {
+			return nil, fmt.Errorf("remote link %q has no global ID", remoteLinkTitle(&desired[i]))
+		}
+		wanted[desired[i].GlobalID] = true
+	}
+
+	existing, _, err := s.GetRemoteLinks(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]*RemoteLink)
+	for i := range *existing {
+		link := &(*existing)[i]
+		if link.GlobalID != "" {
+			current[link.GlobalID] = link
+		}
+	}
+
+	result := &RemoteLinkSyncResult{}
+	for i := range desired {
+		link := &desired[i]
+		have, ok := current[link.GlobalID]
+		if ok && sameRemoteLink(have, link) {
+			result.Unchanged = append(result.Unchanged, link.GlobalID)
+			continue
+		}
+		if _, _, err := s.UpsertRemoteLink(ctx, issueID, link); err != nil {
+			return result, err
+		}
+		if ok {
+			result.Updated = append(result.Updated, link.GlobalID)
+		} else {
+			result.Created = append(result.Created, link.GlobalID)
+		}
+	}
+
+	for i := range *existing {
+		link := &(*existing)[i]
+		if link.GlobalID == "" || wanted[link.GlobalID] || !strings.HasPrefix(link.GlobalID, managedPrefix) {
+			continue
+		}
+		resp, err := s.DeleteRemoteLink(ctx, issueID, link.ID)
+		if err != nil {
+			return result, err
+		}
+		resp.Body.Close()
+		result.Deleted = append(result.Deleted, link.GlobalID)
+	}
+
+	return result, nil
+}
+
+// sameRemoteLink reports whether the existing link has the content of the desired link.
+// Only the fields set in the desired link are compared, as Jira fills in others like the application of the link.
+func sameRemoteLink(have, want *RemoteLink) bool {
+	if have.GlobalID != want.GlobalID || !sameString(have.Relationship, want.Relationship) {
+		return false
+	}
+	if want.Application != nil {
+		if have.Application == nil ||
+			!sameString(have.Application.Type, want.Application.Type) ||
+			!sameString(have.Application.Name, want.Application.Name) {
+			return false
+		}
+	}
+	if want.Object != nil {
+		if have.Object == nil ||
+			!sameString(have.Object.URL, want.Object.URL) ||
+			!sameString(have.Object.Title, want.Object.Title) ||
+			!sameString(have.Object.Summary, want.Object.Summary) ||
+			!sameRemoteLinkIcon(have.Object.Icon, want.Object.Icon) {
+			return false
+		}
+		if want.Object.Status != nil {
+			status := have.Object.Status
+			if status == nil || status.Resolved != want.Object.Status.Resolved || !sameRemoteLinkIcon(status.Icon, want.Object.Status.Icon) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sameRemoteLinkIcon reports whether the existing icon has the fields set in the desired icon
+func sameRemoteLinkIcon(have, want *RemoteLinkIcon) bool {
+	if want == nil {
+		return true
+	}
+	return have != nil &&
+		sameString(have.Url16x16, want.Url16x16) &&
+		sameString(have.Title, want.Title) &&
+		sameString(have.Link, want.Link)
+}
+
+// sameString reports whether have equals want, or want is not set
+func sameString(have, want string) bool {
+	return want == "" || have == want
+}
@@ -0,0 +1,120 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestIssueService_GetRemoteLink(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/10000/remotelink/100", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"id":100,"globalId":"system=http://www.mycompany.com/support&id=1","object":{"url":"http://www.mycompany.com/support?id=1","title":"TSTSUPPORT-1"}}`)
+	})
+	testMux.HandleFunc("/rest/api/2/issue/10000/remotelink", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if got := r.URL.Query().Get("globalId"); got != "system=http://www.mycompany.com/support&id=1" {
+			t.Errorf("Unexpected global ID %q", got)
+		}
+		fmt.Fprint(w, `{"id":100,"globalId":"system=http://www.mycompany.com/support&id=1"}`)
+	})
+
+	link, _, err := testClient.Issue.GetRemoteLink(context.Background(), "10000", 100)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if link.ID != 100 || link.Object.Title != "TSTSUPPORT-1" {
+		t.Errorf("Unexpected remote link %+v", link)
+	}
+
+	link, _, err = testClient.Issue.GetRemoteLinkByGlobalID(context.Background(), "10000", "system=http://www.mycompany.com/support&id=1")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if link.ID != 100 {
+		t.Errorf("Unexpected remote link %+v", link)
+	}
+}
+
+func TestIssueService_DeleteRemoteLink(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/10000/remotelink/100", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	testMux.HandleFunc("/rest/api/2/issue/10000/remotelink", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		testRequestURL(t, r, "/rest/api/2/issue/10000/remotelink?globalId=ci%3Abuild%3A1")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := testClient.Issue.DeleteRemoteLink(context.Background(), "10000", 100); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if _, err := testClient.Issue.DeleteRemoteLinkByGlobalID(context.Background(), "10000", "ci:build:1"); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestIssueService_UpsertRemoteLink_NoGlobalID(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, _, err := testClient.Issue.UpsertRemoteLink(context.Background(), "10000", &RemoteLink{Object: &RemoteLinkObject{Title: "Build"}})
+	if err == nil {
+		t.Error("Expected an error for a remote link without global ID")
+	}
+}
+
+func TestIssueService_SyncRemoteLinks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var upserted []string
+	var deleted []int
+	testMux.HandleFunc("/rest/api/2/issue/10000/remotelink", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `[
+				{"id":1,"globalId":"ci:build:1","application":{},"object":{"url":"https://ci/1","title":"Build 1","icon":{},"status":{"icon":{}}}},
+				{"id":2,"globalId":"ci:build:2","object":{"url":"https://ci/2","title":"Build 2 (running)"}},
+				{"id":3,"globalId":"ci:build:3","object":{"url":"https://ci/3","title":"Build 3"}},
+				{"id":4,"globalId":"wiki:page:1","object":{"url":"https://wiki/1","title":"Spec"}},
+				{"id":5,"object":{"url":"https://example.com","title":"Manual link"}}]`)
+		case http.MethodPost:
+			var link RemoteLink
+			if err := json.NewDecoder(r.Body).Decode(&link); err != nil {
+				t.Fatal(err)
+			}
+			upserted = append(upserted, link.GlobalID)
+			fmt.Fprint(w, `{"id":10,"self":"https://jira/rest/api/2/issue/10000/remotelink/10"}`)
+		}
+	})
+	testMux.HandleFunc("/rest/api/2/issue/10000/remotelink/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		deleted = append(deleted, 3)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	desired := []RemoteLink{
+		{GlobalID: "ci:build:1", Object: &RemoteLinkObject{URL: "https://ci/1", Title: "Build 1"}},
+		{GlobalID: "ci:build:2", Object: &RemoteLinkObject{URL: "https://ci/2", Title: "Build 2 (passed)"}},
+		{GlobalID: "ci:build:4", Object: &RemoteLinkObject{URL: "https://ci/4", Title: "Build 4"}},
+	}
+	result, err := testClient.Issue.SyncRemoteLinks(context.Background(), "10000", desired, "ci:")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	if fmt.Sprint(upserted) != "[ci:build:2 ci:build:4]" || fmt.Sprint(deleted) != "[3]" {
+		t.Errorf("Unexpected requests: upserted %v, deleted %v", upserted, deleted)
+	}
+	if fmt.Sprint(result.Unchanged, result.Updated, result.Created, result.Deleted) != "[ci:build:1] [ci:build:2] [ci:build:4] [ci:build:3]" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+}
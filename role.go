@@ -22,12 +22,13 @@ type Role struct {
 
 // Actor represents a Jira actor
 type Actor struct {
-	ID          int        `json:"id" structs:"id"`
-	DisplayName string     `json:"displayName" structs:"displayName"`
-	Type        string     `json:"type" structs:"type"`
-	Name        string     `json:"name" structs:"name"`
-	AvatarURL   string     `json:"avatarUrl" structs:"avatarUrl"`
-	ActorUser   *ActorUser `json:"actorUser" structs:"actoruser"`
+	ID          int         `json:"id" structs:"id"`
+	DisplayName string      `json:"displayName" structs:"displayName"`
+	Type        string      `json:"type" structs:"type"`
+	Name        string      `json:"name" structs:"name"`
+	AvatarURL   string      `json:"avatarUrl" structs:"avatarUrl"`
+	ActorUser   *ActorUser  `json:"actorUser" structs:"actoruser"`
+	ActorGroup  *ActorGroup `json:"actorGroup,omitempty" structs:"actorGroup,omitempty"`
 }
 
 // ActorUser contains the account id of the actor/user
@@ -35,6 +36,13 @@ type ActorUser struct {
 	AccountID string `json:"accountId" structs:"accountId"`
 }
 
+// ActorGroup contains the group of a group actor
+type ActorGroup struct {
+	Name        string `json:"name" structs:"name"`
+	DisplayName string `json:"displayName" structs:"displayName"`
+	GroupID     string `json:"groupId,omitempty" structs:"groupId,omitempty"`
+}
+
 // GetList returns a list of all available project roles
 //
 // Jira API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v3/#api-api-3-role-get
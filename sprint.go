@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -106,3 +107,252 @@ func (s *SprintService) GetIssue(ctx context.Context, issueID string, options *G
 
 	return issue, resp, nil
 }
+
+// CompleteSprintOptions specifies the optional parameters to SprintService.Complete
+type CompleteSprintOptions struct {
+	// MoveToSprintID is the sprint the incomplete issues are moved to once the sprint is closed.
+	// If zero, Jira moves incomplete issues to the backlog.
+	MoveToSprintID int
+	// CompleteDate is the date the sprint is completed at. Default: now.
+	CompleteDate *time.Time
+}
+
+// sprintSwapPayload is the payload of SprintService.Swap
+type sprintSwapPayload struct {
+	SprintToSwapWith int `json:"sprintToSwapWith"`
+}
+
+// maxIssuesPerMove is the maximum number of issues that can be moved to a sprint or the backlog in one request
+const maxIssuesPerMove = 50
+
+// Create creates a future sprint. Sprint name and origin board ID are required.
+// Start date, end date and goal are optional.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/sprint-createSprint
+func (s *SprintService) Create(ctx context.Context, sprint *Sprint) (*Sprint, *Response, error) {
+	apiEndpoint := "rest/agile/1.0/sprint"
+	req, err := s.client.NewRequest(ctx, http.MethodPost, apiEndpoint, sprint)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responseSprint := new(Sprint)
+	resp, err := s.client.Do(req, responseSprint)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return responseSprint, resp, nil
+}
+
+// Get returns the sprint for the given sprintID.
+// The sprint will only be returned if the user can view the board that the sprint was created on,
+// or view at least one of the issues in the sprint.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/sprint-getSprint
+func (s *SprintService) Get(ctx context.Context, sprintID int) (*Sprint, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/agile/1.0/sprint/%d", sprintID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sprint := new(Sprint)
+	resp, err := s.client.Do(req, sprint)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return sprint, resp, nil
+}
+
+// Update performs a full update of a sprint, identified by sprint.ID.
+// Fields which are not set will be set to null.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/sprint-updateSprint
+func (s *SprintService) Update(ctx context.Context, sprint *Sprint) (*Sprint, *Response, error) {
+	return s.update(ctx, http.MethodPut, sprint.ID, sprint)
+}
+
+// PartialUpdate performs a partial update of a sprint.
+// Only the fields which are set in sprint are updated.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/sprint-partiallyUpdateSprint
+func (s *SprintService) PartialUpdate(ctx context.Context, sprintID int, sprint *Sprint) (*Sprint, *Response, error) {
+	return s.update(ctx, http.MethodPost, sprintID, sprint)
+}
+
+func (s *SprintService) update(ctx context.Context, method string, sprintID int, sprint *Sprint) (*Sprint, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/agile/1.0/sprint/%d", sprintID)
+	req, err := s.client.NewRequest(ctx, method, apiEndpoint, sprint)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responseSprint := new(Sprint)
+	resp, err := s.client.Do(req, responseSprint)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return responseSprint, resp, nil
+}
+
+// Delete deletes a sprint. Once a sprint is deleted, all issues in the sprint will be moved to the backlog.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/sprint-deleteSprint
+// Caller must close resp.Body
+func (s *SprintService) Delete(ctx context.Context, sprintID int) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/agile/1.0/sprint/%d", sprintID)
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, apiEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		err = NewJiraError(resp, err)
+	}
+	return resp, err
+}
+
+// Start starts a future sprint. A sprint can only be started with a start and an end date.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/sprint-partiallyUpdateSprint
+func (s *SprintService) Start(ctx context.Context, sprintID int, startDate, endDate time.Time) (*Sprint, *Response, error) {
+	sprint := &Sprint{
+		State:     "active",
+		StartDate: &startDate,
+		EndDate:   &endDate,
+	}
+	return s.PartialUpdate(ctx, sprintID, sprint)
+}
+
+// Complete closes an active sprint.
+// Jira moves the issues of the sprint which are not in a done status category to the backlog
+// when the sprint is closed. If options.MoveToSprintID is set, they are moved to that sprint after
+// the sprint is closed, so they are still reported as not completed in the closed sprint.
+// Sub-tasks move with their parents.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/sprint-partiallyUpdateSprint
+func (s *SprintService) Complete(ctx context.Context, sprintID int, options *CompleteSprintOptions) (*Sprint, *Response, error) {
+	if options == nil {
+		options = &CompleteSprintOptions{}
+	}
+
+	var incomplete []string
+	if options.MoveToSprintID != 0 {
+		jql := fmt.Sprintf("sprint = %d AND statusCategory != %s AND issuetype not in subTaskIssueTypes()", sprintID, StatusCategoryComplete)
+		searchOptions := &SearchOptions{MaxResults: 50, Fields: []string{"key"}}
+		err := s.client.Issue.SearchPages(ctx, jql, searchOptions, func(issue Issue) error {
+			incomplete = append(incomplete, issue.Key)
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	completeDate := time.Now()
+	if options.CompleteDate != nil {
+		completeDate = *options.CompleteDate
+	}
+	sprint, resp, err := s.PartialUpdate(ctx, sprintID, &Sprint{
+		State:        "closed",
+		CompleteDate: &completeDate,
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	for start := 0; start < len(incomplete); start += maxIssuesPerMove {
+		end := start + maxIssuesPerMove
+		if end > len(incomplete) {
+			end = len(incomplete)
+		}
+
+		moveResp, err := s.MoveIssuesToSprint(ctx, options.MoveToSprintID, incomplete[start:end])
+		if err != nil {
+			return sprint, moveResp, err
+		}
+		moveResp.Body.Close()
+	}
+	return sprint, resp, nil
+}
+
+// MoveIssuesToBacklog moves issues to the backlog, i.e. removes them from all sprints.
+// The maximum number of issues that can be moved in one operation is 50.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/backlog-moveIssuesToBacklog
+// Caller must close resp.Body
+func (s *SprintService) MoveIssuesToBacklog(ctx context.Context, issueIDs []string) (*Response, error) {
+	apiEndpoint := "rest/agile/1.0/backlog/issue"
+
+	payload := IssuesWrapper{Issues: issueIDs}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, apiEndpoint, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		err = NewJiraError(resp, err)
+	}
+	return resp, err
+}
+
+// Swap swaps the position of the sprint with the second sprint.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/sprint-swapSprint
+// Caller must close resp.Body
+func (s *SprintService) Swap(ctx context.Context, sprintID, sprintToSwapWith int) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/agile/1.0/sprint/%d/swap", sprintID)
+
+	payload := sprintSwapPayload{SprintToSwapWith: sprintToSwapWith}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, apiEndpoint, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		err = NewJiraError(resp, err)
+	}
+	return resp, err
+}
+
+// GetPropertiesKeys returns the keys of all properties for the sprint.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/sprint-getPropertiesKeys
+func (s *SprintService) GetPropertiesKeys(ctx context.Context, sprintID int) (*PropertyKeys, *Response, error) {
+	return s.client.Property.GetKeys(ctx, SprintEntity(sprintID))
+}
+
+// GetProperty returns the value of the property with the given key from the sprint.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/sprint-getProperty
+func (s *SprintService) GetProperty(ctx context.Context, sprintID int, propertyKey string) (*EntityProperty, *Response, error) {
+	return s.client.Property.Get(ctx, SprintEntity(sprintID), propertyKey)
+}
+
+// SetProperty sets the value of the property with the given key on the sprint.
+// The value must be serializable to JSON.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/sprint-setProperty
+// Caller must close resp.Body
+func (s *SprintService) SetProperty(ctx context.Context, sprintID int, propertyKey string, value interface{}) (*Response, error) {
+	return s.client.Property.Set(ctx, SprintEntity(sprintID), propertyKey, value)
+}
+
+// DeleteProperty removes the property with the given key from the sprint.
+//
+// Jira API docs: https://docs.atlassian.com/jira-software/REST/7.3.1/#agile/1.0/sprint-deleteProperty
+// Caller must close resp.Body
+func (s *SprintService) DeleteProperty(ctx context.Context, sprintID int, propertyKey string) (*Response, error) {
+	return s.client.Property.Delete(ctx, SprintEntity(sprintID), propertyKey)
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestSprintService_MoveIssuesToSprint(t *testing.T) {
@@ -115,3 +116,298 @@ func TestSprintService_GetIssue(t *testing.T) {
 		t.Errorf("Error given: %s", err)
 	}
 }
+
+func TestSprintService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testAPIEndpoint := "/rest/agile/1.0/sprint"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testRequestURL(t, r, testAPIEndpoint)
+
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Got error: %v", err)
+		}
+		if _, ok := payload["id"]; ok {
+			t.Error("Expected no id in create payload")
+		}
+		if payload["goal"] != "Ship it" {
+			t.Errorf("Expected goal in payload, got %v", payload["goal"])
+		}
+		fmt.Fprint(w, `{"id":37,"self":"http://www.example.com/jira/rest/agile/1.0/sprint/37","state":"future","name":"sprint 1","originBoardId":5,"goal":"Ship it","createdDate":"2015-04-11T15:22:00.000+10:00"}`)
+	})
+
+	sprint, _, err := testClient.Sprint.Create(context.Background(), &Sprint{Name: "sprint 1", OriginBoardID: 5, Goal: "Ship it"})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if sprint.ID != 37 {
+		t.Errorf("Expected sprint ID 37, got %d", sprint.ID)
+	}
+	if sprint.CreatedDate == nil {
+		t.Error("Expected created date to be decoded")
+	}
+}
+
+func TestSprintService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testAPIEndpoint := "/rest/agile/1.0/sprint/37"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testRequestURL(t, r, testAPIEndpoint)
+		fmt.Fprint(w, `{"id":37,"state":"closed","name":"sprint 1","startDate":"2015-04-11T15:22:00.000+10:00","endDate":"2015-04-20T01:22:00.000+10:00","completeDate":"2015-04-20T11:04:00.000+10:00","originBoardId":5,"goal":"sprint 1 goal"}`)
+	})
+
+	sprint, _, err := testClient.Sprint.Get(context.Background(), 37)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if sprint.Goal != "sprint 1 goal" {
+		t.Errorf("Expected goal %q, got %q", "sprint 1 goal", sprint.Goal)
+	}
+	if sprint.CompleteDate == nil {
+		t.Error("Expected complete date to be decoded")
+	}
+}
+
+func TestSprintService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testAPIEndpoint := "/rest/agile/1.0/sprint/37"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		testRequestURL(t, r, testAPIEndpoint)
+		fmt.Fprint(w, `{"id":37,"state":"future","name":"renamed"}`)
+	})
+
+	sprint, _, err := testClient.Sprint.Update(context.Background(), &Sprint{ID: 37, Name: "renamed", State: "future"})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if sprint.Name != "renamed" {
+		t.Errorf("Expected name %q, got %q", "renamed", sprint.Name)
+	}
+}
+
+func TestSprintService_Start(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testAPIEndpoint := "/rest/agile/1.0/sprint/37"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testRequestURL(t, r, testAPIEndpoint)
+
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Got error: %v", err)
+		}
+		if payload["state"] != "active" {
+			t.Errorf("Expected state active, got %v", payload["state"])
+		}
+		if payload["startDate"] == nil || payload["endDate"] == nil {
+			t.Errorf("Expected start and end date, got %v", payload)
+		}
+		fmt.Fprint(w, `{"id":37,"state":"active"}`)
+	})
+
+	start := time.Date(2022, 1, 3, 9, 0, 0, 0, time.UTC)
+	sprint, _, err := testClient.Sprint.Start(context.Background(), 37, start, start.AddDate(0, 0, 14))
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if sprint.State != "active" {
+		t.Errorf("Expected state active, got %s", sprint.State)
+	}
+}
+
+func TestSprintService_Complete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var moved []string
+	closed := false
+	testMux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if jql := r.URL.Query().Get("jql"); jql != "sprint = 37 AND statusCategory != done AND issuetype not in subTaskIssueTypes()" {
+			t.Errorf("Unexpected JQL %q", jql)
+		}
+		fmt.Fprint(w, `{"startAt":0,"maxResults":50,"total":2,"issues":[{"key":"TEST-1"},{"key":"TEST-2"}]}`)
+	})
+	testMux.HandleFunc("/rest/agile/1.0/sprint/38/issue", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		if !closed {
+			t.Error("Expected the sprint to be closed before incomplete issues are moved")
+		}
+		var payload IssuesWrapper
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Got error: %v", err)
+		}
+		moved = append(moved, payload.Issues...)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	testMux.HandleFunc("/rest/agile/1.0/sprint/37", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		closed = true
+		fmt.Fprint(w, `{"id":37,"state":"closed"}`)
+	})
+
+	sprint, _, err := testClient.Sprint.Complete(context.Background(), 37, &CompleteSprintOptions{MoveToSprintID: 38})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if sprint.State != "closed" {
+		t.Errorf("Expected state closed, got %s", sprint.State)
+	}
+	if !reflect.DeepEqual(moved, []string{"TEST-1", "TEST-2"}) {
+		t.Errorf("Expected TEST-1 and TEST-2 to be moved, got %v", moved)
+	}
+}
+
+func TestSprintService_Complete_Backlog(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected no search when incomplete issues go to the backlog")
+	})
+	testMux.HandleFunc("/rest/agile/1.0/sprint/37", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		fmt.Fprint(w, `{"id":37,"state":"closed"}`)
+	})
+
+	if _, _, err := testClient.Sprint.Complete(context.Background(), 37, nil); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+}
+
+func TestSprintService_MoveIssuesToBacklog(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testAPIEndpoint := "/rest/agile/1.0/backlog/issue"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testRequestURL(t, r, testAPIEndpoint)
+
+		var payload IssuesWrapper
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Got error: %v", err)
+		}
+		if !reflect.DeepEqual(payload.Issues, []string{"KEY-1"}) {
+			t.Errorf("Expected KEY-1 in payload, got %v", payload.Issues)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := testClient.Sprint.MoveIssuesToBacklog(context.Background(), []string{"KEY-1"})
+	if err != nil {
+		t.Errorf("Got error: %v", err)
+	}
+}
+
+func TestSprintService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testAPIEndpoint := "/rest/agile/1.0/sprint/37"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		testRequestURL(t, r, testAPIEndpoint)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := testClient.Sprint.Delete(context.Background(), 37)
+	if err != nil {
+		t.Errorf("Got error: %v", err)
+	}
+}
+
+func TestSprintService_Swap(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testAPIEndpoint := "/rest/agile/1.0/sprint/37/swap"
+
+	testMux.HandleFunc(testAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testRequestURL(t, r, testAPIEndpoint)
+
+		var payload sprintSwapPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Got error: %v", err)
+		}
+		if payload.SprintToSwapWith != 38 {
+			t.Errorf("Expected sprint 38 in payload, got %d", payload.SprintToSwapWith)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := testClient.Sprint.Swap(context.Background(), 37, 38)
+	if err != nil {
+		t.Errorf("Got error: %v", err)
+	}
+}
+
+func TestSprintService_Properties(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/rest/agile/1.0/sprint/37/properties", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"keys":[{"self":"http://www.example.com/jira/rest/agile/1.0/sprint/37/properties/team","key":"team"}]}`)
+	})
+	testMux.HandleFunc("/rest/agile/1.0/sprint/37/properties/team", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"key":"team","value":{"name":"Avengers"}}`)
+		case http.MethodPut:
+			var payload map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Errorf("Got error: %v", err)
+			}
+			if payload["name"] != "Avengers" {
+				t.Errorf("Expected property value in payload, got %v", payload)
+			}
+			w.WriteHeader(http.StatusOK)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+
+	keys, _, err := testClient.Sprint.GetPropertiesKeys(context.Background(), 37)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(keys.Keys) != 1 || keys.Keys[0].Key != "team" {
+		t.Errorf("Expected property key team, got %+v", keys.Keys)
+	}
+
+	property, _, err := testClient.Sprint.GetProperty(context.Background(), 37, "team")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if property.Key != "team" {
+		t.Errorf("Expected property team, got %s", property.Key)
+	}
+
+	if _, err := testClient.Sprint.SetProperty(context.Background(), 37, "team", map[string]string{"name": "Avengers"}); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if _, err := testClient.Sprint.DeleteProperty(context.Background(), 37, "team"); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
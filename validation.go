@@ -0,0 +1,322 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FieldViolation describes a field of an issue which does not match the create metadata.
+type FieldViolation struct {
+	// FieldID is the ID of the field, e.g. "customfield_10010".
+	FieldID string
+	// Name is the name of the field as shown in Jira, or the field ID if the field is unknown.
+	Name    string
+	Message string
+}
+
+func (v FieldViolation) String() string {
+	return fmt.Sprintf("field %q (%s) %s", v.Name, v.FieldID, v.Message)
+}
+
+// ValidationError lists all fields of an issue which do not match the create metadata.
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.String()
+	}
+	return "invalid issue: " + strings.Join(messages, "; ")
+}
+
+const (
+	epicLinkCustomType = "com.pyxis.greenhopper.jira:gh-epic-link"
+	sprintCustomType   = "com.pyxis.greenhopper.jira:gh-sprint"
+)
+
+// alwaysCreatableFields are accepted on create even if the create metadata does not list them
+var alwaysCreatableFields = map[string]bool{
+	"project":   true,
+	"issuetype": true,
+	"parent":    true,
+}
+
+// Validate checks the fields of the issue against the create metadata of the issue type.
+// It checks that all required fields are set, all fields are on the create screen, values have the type
+// of the field schema and are among the allowed values. All violations are returned at once as *ValidationError.
+func (t *MetaIssueType) Validate(issue *Issue) error {
+	fields, err := metaIssueTypeFields(t)
+	if err != nil {
+		return err
+	}
+	return validateIssueFields(fields, issue)
+}
+
+// ValidateIssueFields checks the fields of the issue against the fields returned by IssueService.GetProjectIssueFields,
+// like MetaIssueType.Validate.
+func ValidateIssueFields(fields []ProjectIssueField, issue *Issue) error {
+	byID := make(map[string]ProjectIssueField, len(fields))
+	for _, f := range fields {
+		byID[f.FieldID] = f
+	}
+	return validateIssueFields(byID, issue)
+}
+
+func validateIssueFields(fields map[string]ProjectIssueField, issue *Issue) error {
+	values := map[string]interface{}{}
+	if issue.Fields != nil {
+		b, err := json.Marshal(issue.Fields)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(b, &values); err != nil {
+			return err
+		}
+	}
+
+	if violations := validateFieldValues(fields, values); len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// validateFieldValues checks field values decoded from JSON, sorted by field ID
+func validateFieldValues(fields map[string]ProjectIssueField, values map[string]interface{}) []FieldViolation {
+	var violations []FieldViolation
+	for id, value := range values {
+		field, ok := fields[id]
+		if !ok {
+			if !alwaysCreatableFields[id] {
+				violations = append(violations, FieldViolation{FieldID: id, Name: id, Message: "is not on the create screen"})
+			}
+			continue
+		}
+		if isEmptyFieldValue(value) {
+			continue
+		}
+		for _, message := range validateFieldValue(&field, value) {
+			violations = append(violations, FieldViolation{FieldID: id, Name: field.Name, Message: message})
+		}
+	}
+
+	for id, field := range fields {
+		if field.Required && !field.HasDefaultValue && isEmptyFieldValue(values[id]) {
+			violations = append(violations, FieldViolation{FieldID: id, Name: field.Name, Message: "is required"})
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool { return violations[i].FieldID < violations[j].FieldID })
+	return violations
+}
+
+func isEmptyFieldValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// validateFieldValue checks a value against the schema and allowed values of the field
+func validateFieldValue(field *ProjectIssueField, value interface{}) []string {
+	schema := field.Schema
+	switch {
+	case schema.Custom == sprintCustomType:
+		// The sprint is set by its ID, even though the schema describes the returned list of sprints
+		if _, ok := value.(float64); !ok {
+			return []string{fmt.Sprintf("must be a sprint ID, got %s", describeValue(value))}
+		}
+		return nil
+	case schema.Custom == epicLinkCustomType:
+		if key, ok := value.(string); !ok || !issueKeyPattern.MatchString(key) {
+			return []string{fmt.Sprintf("must be the key of an epic, got %s", describeValue(value))}
+		}
+		return nil
+	case schema.System == "timetracking" || schema.Type == "timetracking":
+		return validateTimeTracking(value)
+	case schema.Type == "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("must be a list, got %s", describeValue(value))}
+		}
+		var messages []string
+		for i, item := range items {
+			for _, message := range validateSchemaValue(field, schema.Items, item) {
+				messages = append(messages, fmt.Sprintf("item %d %s", i+1, message))
+			}
+		}
+		return messages
+	}
+	return validateSchemaValue(field, schema.Type, value)
+}
+
+// validateSchemaValue checks a single value of the schema type
+func validateSchemaValue(field *ProjectIssueField, schemaType string, value interface{}) []string {
+	switch schemaType {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return []string{fmt.Sprintf("must be a string, got %s", describeValue(value))}
+		}
+		if field.Schema.System == "labels" && strings.ContainsAny(s, " \t\n") {
+			return []string{fmt.Sprintf("must not contain spaces, got %q", s)}
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return []string{fmt.Sprintf("must be a number, got %s", describeValue(value))}
+		}
+	case "date":
+		s, ok := value.(string)
+		if _, err := time.Parse("2006-01-02", s); !ok || err != nil {
+			return []string{fmt.Sprintf("must be a date like 2006-01-02, got %s", describeValue(value))}
+		}
+	case "datetime":
+		s, ok := value.(string)
+		if !ok || !isJiraDateTime(s) {
+			return []string{fmt.Sprintf("must be a date and time like 2006-01-02T15:04:05.000-0700, got %s", describeValue(value))}
+		}
+	case "user":
+		return validateReference(field, value, "accountId", "name", "key")
+	case "option":
+		return validateReference(field, value, "id", "value")
+	case "option-with-child":
+		return validateCascadingOption(field, value)
+	case "project":
+		return validateReference(field, value, "id", "key")
+	case "version", "component", "priority", "resolution", "issuetype", "group", "securitylevel":
+		return validateReference(field, value, "id", "name")
+	}
+	return nil
+}
+
+// validateReference checks that value is an object identified by one of the keys and, if the field
+// has allowed values, that it references one of them
+func validateReference(field *ProjectIssueField, value interface{}, keys ...string) []string {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return []string{fmt.Sprintf("must be an object with one of %s, got %s", strings.Join(keys, ", "), describeValue(value))}
+	}
+	found := false
+	for _, k := range keys {
+		if _, ok := m[k]; ok {
+			found = true
+		}
+	}
+	if !found {
+		return []string{fmt.Sprintf("must be an object with one of %s, got %s", strings.Join(keys, ", "), describeValue(value))}
+	}
+	if len(field.AllowedValues) > 0 && findAllowedValue(field.AllowedValues, m) == nil {
+		return []string{fmt.Sprintf("has value %s, which is not allowed; allowed are %s", describeValue(value), allowedValueNames(field.AllowedValues))}
+	}
+	return nil
+}
+
+// validateCascadingOption checks a value like {"value": "Europe", "child": {"value": "Berlin"}}
+func validateCascadingOption(field *ProjectIssueField, value interface{}) []string {
+	if messages := validateReference(&ProjectIssueField{}, value, "id", "value"); len(messages) > 0 {
+		return messages
+	}
+	m := value.(map[string]interface{})
+	parent := findAllowedValue(field.AllowedValues, m)
+	if len(field.AllowedValues) > 0 && parent == nil {
+		return []string{fmt.Sprintf("has value %s, which is not allowed; allowed are %s", describeValue(value), allowedValueNames(field.AllowedValues))}
+	}
+
+	child, ok := m["child"]
+	if !ok || parent == nil {
+		return nil
+	}
+	if messages := validateReference(&ProjectIssueField{AllowedValues: parent.Children}, child, "id", "value"); len(messages) > 0 {
+		for i := range messages {
+			messages[i] = "child " + messages[i]
+		}
+		return messages
+	}
+	return nil
+}
+
+// validateTimeTracking checks a value like {"originalEstimate": "1d 2h", "remainingEstimate": "3h"}
+func validateTimeTracking(value interface{}) []string {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return []string{fmt.Sprintf("must be an object with originalEstimate or remainingEstimate, got %s", describeValue(value))}
+	}
+	var messages []string
+	for key, estimate := range m {
+		if key != "originalEstimate" && key != "remainingEstimate" {
+			messages = append(messages, fmt.Sprintf("has unknown property %q", key))
+			continue
+		}
+		s, ok := estimate.(string)
+		if !ok {
+			messages = append(messages, fmt.Sprintf("%s must be a duration like \"1d 2h\", got %s", key, describeValue(estimate)))
+			continue
+		}
+		if _, err := ParseDuration(s); err != nil {
+			messages = append(messages, fmt.Sprintf("%s: %s", key, err))
+		}
+	}
+	sort.Strings(messages)
+	return messages
+}
+
+// findAllowedValue returns the allowed value referenced by the ID, key, name or value of m
+func findAllowedValue(allowed []AllowedValue, m map[string]interface{}) *AllowedValue {
+	id, _ := m["id"].(string)
+	key, _ := m["key"].(string)
+	name, _ := m["name"].(string)
+	value, _ := m["value"].(string)
+	for i, a := range allowed {
+		switch {
+		case id != "" && a.ID == id,
+			key != "" && a.Key == key,
+			name != "" && strings.EqualFold(a.Name, name),
+			value != "" && strings.EqualFold(a.Value, value):
+			return &allowed[i]
+		}
+	}
+	return nil
+}
+
+func allowedValueNames(allowed []AllowedValue) string {
+	names := make([]string, len(allowed))
+	for i, a := range allowed {
+		switch {
+		case a.Value != "":
+			names[i] = a.Value
+		case a.Name != "":
+			names[i] = a.Name
+		default:
+			names[i] = a.ID
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func isJiraDateTime(s string) bool {
+	for _, layout := range []string{"2006-01-02T15:04:05.000-0700", "2006-01-02T15:04:05-0700", time.RFC3339} {
+		if _, err := time.Parse(layout, s); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func describeValue(value interface{}) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
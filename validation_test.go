@@ -0,0 +1,112 @@
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/trivago/tgo/tcontainer"
+)
+
+const validationTestFields = `{
+	"summary":{"required":true,"name":"Summary","schema":{"type":"string","system":"summary"}},
+	"reporter":{"required":true,"hasDefaultValue":true,"name":"Reporter","schema":{"type":"user","system":"reporter"}},
+	"priority":{"required":true,"name":"Priority","schema":{"type":"priority","system":"priority"},"allowedValues":[{"id":"1","name":"High"},{"id":"2","name":"Low"}]},
+	"labels":{"name":"Labels","schema":{"type":"array","items":"string","system":"labels"}},
+	"fixVersions":{"name":"Fix Version/s","schema":{"type":"array","items":"version","system":"fixVersions"},"allowedValues":[{"id":"10","name":"1.0"}]},
+	"duedate":{"name":"Due Date","schema":{"type":"date","system":"duedate"}},
+	"timetracking":{"name":"Time tracking","schema":{"type":"timetracking","system":"timetracking"}},
+	"customfield_10001":{"name":"Story Points","schema":{"type":"number","custom":"com.atlassian.jira.plugin.system.customfieldtypes:float"}},
+	"customfield_10002":{"name":"Reviewers","schema":{"type":"array","items":"user","custom":"com.atlassian.jira.plugin.system.customfieldtypes:multiuserpicker"}},
+	"customfield_10003":{"name":"Location","schema":{"type":"option-with-child","custom":"com.atlassian.jira.plugin.system.customfieldtypes:cascadingselect"},
+		"allowedValues":[{"id":"20","value":"Europe","children":[{"id":"21","value":"Berlin"}]}]},
+	"customfield_10004":{"name":"Sprint","schema":{"type":"array","items":"string","custom":"com.pyxis.greenhopper.jira:gh-sprint"}},
+	"customfield_10005":{"name":"Epic Link","schema":{"type":"any","custom":"com.pyxis.greenhopper.jira:gh-epic-link"}},
+	"customfield_10006":{"name":"Team","schema":{"type":"option"},"allowedValues":[{"id":"30","value":"Red"}]}
+}`
+
+func validationTestIssueType(t *testing.T) *MetaIssueType {
+	fields := tcontainer.NewMarshalMap()
+	if err := json.Unmarshal([]byte(validationTestFields), &fields); err != nil {
+		t.Fatal(err)
+	}
+	return &MetaIssueType{Name: "Task", Fields: fields}
+}
+
+func TestMetaIssueType_Validate(t *testing.T) {
+	issueType := validationTestIssueType(t)
+
+	valid := &Issue{Fields: &IssueFields{
+		Summary:  "Valid",
+		Priority: &Priority{Name: "high"},
+		Labels:   []string{"backend"},
+		Unknowns: tcontainer.MarshalMap{
+			"fixVersions":       []map[string]string{{"name": "1.0"}},
+			"duedate":           "2024-01-31",
+			"timetracking":      map[string]string{"originalEstimate": "1d 2h"},
+			"customfield_10001": 3.5,
+			"customfield_10002": []map[string]string{{"accountId": "abc"}},
+			"customfield_10003": map[string]interface{}{"value": "Europe", "child": map[string]string{"value": "Berlin"}},
+			"customfield_10004": 7,
+			"customfield_10005": "PROJ-1",
+			"customfield_10006": map[string]string{"id": "30"},
+		},
+	}}
+	if err := issueType.Validate(valid); err != nil {
+		t.Errorf("Expected a valid issue, got %s", err)
+	}
+
+	invalid := &Issue{Fields: &IssueFields{
+		Labels: []string{"two words"},
+		Unknowns: tcontainer.MarshalMap{
+			"fixVersions":       []map[string]string{{"name": "2.0"}},
+			"duedate":           "31.01.2024",
+			"timetracking":      map[string]string{"originalEstimate": "soon"},
+			"customfield_10001": "three",
+			"customfield_10002": []string{"bob"},
+			"customfield_10003": map[string]interface{}{"value": "Europe", "child": map[string]string{"value": "Paris"}},
+			"customfield_10004": []string{"Sprint 1"},
+			"customfield_10005": "not a key",
+			"customfield_10006": map[string]string{"value": "Blue"},
+			"customfield_99999": "unknown",
+		},
+	}}
+	err := issueType.Validate(invalid)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+
+	want := []string{
+		"customfield_10001", "customfield_10002", "customfield_10003", "customfield_10004", "customfield_10005",
+		"customfield_10006", "customfield_99999", "duedate", "fixVersions", "labels", "priority", "summary", "timetracking",
+	}
+	var got []string
+	for _, v := range validationErr.Violations {
+		got = append(got, v.FieldID)
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected violations of %v, got %v", want, validationErr.Violations)
+	}
+	if !strings.Contains(err.Error(), `field "Team" (customfield_10006) has value {"value":"Blue"}, which is not allowed; allowed are Red`) {
+		t.Errorf("Unexpected error message %s", err)
+	}
+}
+
+func TestValidateIssueFields(t *testing.T) {
+	fields := []ProjectIssueField{
+		{FieldID: "summary", Name: "Summary", Required: true, Schema: FieldSchema{Type: "string"}},
+		{FieldID: "customfield_10001", Name: "Story Points", Schema: FieldSchema{Type: "number"}},
+	}
+
+	err := ValidateIssueFields(fields, &Issue{Fields: &IssueFields{Unknowns: tcontainer.MarshalMap{"customfield_10001": "3"}}})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Violations) != 2 {
+		t.Fatalf("Expected 2 violations, got %v", err)
+	}
+
+	if err := ValidateIssueFields(fields, &Issue{Fields: &IssueFields{Summary: "Valid", Unknowns: tcontainer.MarshalMap{"customfield_10001": 3}}}); err != nil {
+		t.Errorf("Expected a valid issue, got %s", err)
+	}
+}
@@ -0,0 +1,243 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// User returns the watcher as User.
+func (w *Watcher) User() User {
+	return User{
+		Self:        w.Self,
+		Name:        w.Name,
+		AccountID:   w.AccountID,
+		DisplayName: w.DisplayName,
+		Active:      w.Active,
+	}
+}
+
+// userParam returns the query parameter and value identifying the user on the instance:
+// the account ID on Jira Cloud and the user name on Jira Server / Data Center.
+func (s *IssueService) userParam(ctx context.Context, user *User) (string, string, error) {
+	cloud, err := s.client.Configuration.IsCloud(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	if cloud {
+		if user.AccountID == "" {
+			return "", "", fmt.Errorf("user %q has no account ID, which is required on Jira Cloud", user.DisplayName)
+		}
+		return "accountId", user.AccountID, nil
+	}
+	if user.Name == "" {
+		return "", "", fmt.Errorf("user %q has no name, which is required on Jira Server / Data Center", user.DisplayName)
+	}
+	return "username", user.Name, nil
+}
+
+// sameUser reports whether both users are the same, comparing account IDs and falling back to names
+func sameUser(a, b *User) bool {
+	if a.AccountID != "" && b.AccountID != "" {
+		return a.AccountID == b.AccountID
+	}
+	return a.Name != "" && a.Name == b.Name
+}
+
+// GetWatches returns the watchers of the issue as reported by Jira, without fetching their full user profiles.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/issue-getIssueWatchers
+func (s *IssueService) GetWatches(ctx context.Context, issueID string) (*Watches, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/watchers", issueID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	watches := new(Watches)
+	resp, err := s.client.Do(req, watches)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return watches, resp, nil
+}
+
+// AddWatcherUser adds the user as watcher to the issue.
+// The user is identified by account ID on Jira Cloud and by name on Jira Server / Data Center.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/issue-addWatcher
+// Caller must close resp.Body
+func (s *IssueService) AddWatcherUser(ctx context.Context, issueID string, user *User) (*Response, error) {
+	_, value, err := s.userParam(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/watchers", issueID)
+	req, err := s.client.NewRequest(ctx, http.MethodPost, apiEndpoint, value)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		err = NewJiraError(resp, err)
+	}
+	return resp, err
+}
+
+// RemoveWatcherUser removes the user from the watchers of the issue.
+// The user is identified by account ID on Jira Cloud and by name on Jira Server / Data Center.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/issue-removeWatcher
+// Caller must close resp.Body
+func (s *IssueService) RemoveWatcherUser(ctx context.Context, issueID string, user *User) (*Response, error) {
+	param, value, err := s.userParam(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/watchers?%s=%s", issueID, param, url.QueryEscape(value))
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, apiEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		err = NewJiraError(resp, err)
+	}
+	return resp, err
+}
+
+// WatcherChanges lists the watchers added to and removed from an issue by IssueService.EnsureWatchers.
+type WatcherChanges struct {
+	IssueID string
+	Added   []User
+	Removed []User
+}
+
+// EnsureWatchers makes the given users watch every issue.
+// If exclusive is true, all other watchers are removed, so the watchers of every issue are exactly the given users.
+// The changes of every issue are returned, including the changes made before an error occurred.
+func (s *IssueService) EnsureWatchers(ctx context.Context, issueIDs []string, users []User, exclusive bool) ([]WatcherChanges, error) {
+	var changes []WatcherChanges
+	for _, issueID := range issueIDs {
+		watches, _, err := s.GetWatches(ctx, issueID)
+		if err != nil {
+			return changes, err
+		}
+
+		c := WatcherChanges{IssueID: issueID}
+		for i := range users {
+			watching := false
+			for _, w := range watches.Watchers {
+				watcher := w.User()
+				if sameUser(&users[i], &watcher) {
+					watching = true
+					break
+				}
+			}
+			if watching {
+				continue
+			}
+			resp, err := s.AddWatcherUser(ctx, issueID, &users[i])
+			if err != nil {
+				changes = append(changes, c)
+				return changes, err
+			}
+			resp.Body.Close()
+			c.Added = append(c.Added, users[i])
+		}
+
+		if exclusive {
+			for _, w := range watches.Watchers {
+				watcher := w.User()
+				wanted := false
+				for i := range users {
+					if sameUser(&users[i], &watcher) {
+						wanted = true
+						break
+					}
+				}
+				if wanted {
+					continue
+				}
+				resp, err := s.RemoveWatcherUser(ctx, issueID, &watcher)
+				if err != nil {
+					changes = append(changes, c)
+					return changes, err
+				}
+				resp.Body.Close()
+				c.Removed = append(c.Removed, watcher)
+			}
+		}
+
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+// Votes represents the votes of an issue.
+type Votes struct {
+	Self     string `json:"self,omitempty" structs:"self,omitempty"`
+	Votes    int    `json:"votes" structs:"votes"`
+	HasVoted bool   `json:"hasVoted" structs:"hasVoted"`
+	// Voters are only returned to users with the permission to view voters and watchers.
+	Voters []User `json:"voters,omitempty" structs:"voters,omitempty"`
+}
+
+// GetVotes returns the votes of the issue.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/issue-getVotes
+func (s *IssueService) GetVotes(ctx context.Context, issueID string) (*Votes, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/votes", issueID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	votes := new(Votes)
+	resp, err := s.client.Do(req, votes)
+	if err != nil {
+		return nil, resp, NewJiraError(resp, err)
+	}
+	return votes, resp, nil
+}
+
+// AddVote casts a vote for the issue in the name of the current user.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/issue-addVote
+// Caller must close resp.Body
+func (s *IssueService) AddVote(ctx context.Context, issueID string) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/votes", issueID)
+	req, err := s.client.NewRequest(ctx, http.MethodPost, apiEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		err = NewJiraError(resp, err)
+	}
+	return resp, err
+}
+
+// RemoveVote removes the vote of the current user from the issue.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/issue-removeVote
+// Caller must close resp.Body
+func (s *IssueService) RemoveVote(ctx context.Context, issueID string) (*Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/votes", issueID)
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, apiEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		err = NewJiraError(resp, err)
+	}
+	return resp, err
+}
@@ -0,0 +1,144 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func handleServerInfo(t *testing.T, deploymentType string) {
+	testMux.HandleFunc("/rest/api/2/serverInfo", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprintf(w, `{"baseUrl":"https://jira.example.com","version":"8.13.0","deploymentType":%q}`, deploymentType)
+	})
+}
+
+func TestIssueService_GetWatchers_Server(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/10002/watchers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"watchCount":1,"watchers":[{"name":"fred","displayName":"Fred F. User","active":true}]}`)
+	})
+
+	watchers, _, err := testClient.Issue.GetWatchers(context.Background(), "10002")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(*watchers) != 1 || (*watchers)[0].Name != "fred" {
+		t.Errorf("Unexpected watchers %+v", *watchers)
+	}
+}
+
+func TestIssueService_AddWatcherUser(t *testing.T) {
+	for _, tt := range []struct {
+		deploymentType string
+		want           string
+	}{
+		{DeploymentTypeCloud, "5b10ac8d82e05b22cc7d4ef5"},
+		{DeploymentTypeServer, "fred"},
+	} {
+		setup()
+		handleServerInfo(t, tt.deploymentType)
+		testMux.HandleFunc("/rest/api/2/issue/10002/watchers", func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodPost)
+			var body string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body != tt.want {
+				t.Errorf("%s: expected body %q, got %q (%v)", tt.deploymentType, tt.want, body, err)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		user := &User{Name: "fred", AccountID: "5b10ac8d82e05b22cc7d4ef5"}
+		if _, err := testClient.Issue.AddWatcherUser(context.Background(), "10002", user); err != nil {
+			t.Errorf("%s: error given: %s", tt.deploymentType, err)
+		}
+		teardown()
+	}
+}
+
+func TestIssueService_AddWatcherUser_MissingAccountID(t *testing.T) {
+	setup()
+	defer teardown()
+	handleServerInfo(t, DeploymentTypeCloud)
+
+	if _, err := testClient.Issue.AddWatcherUser(context.Background(), "10002", &User{Name: "fred"}); err == nil {
+		t.Error("Expected an error for a user without account ID on Jira Cloud")
+	}
+}
+
+func TestIssueService_RemoveWatcherUser(t *testing.T) {
+	setup()
+	defer teardown()
+	handleServerInfo(t, DeploymentTypeDataCenter)
+	testMux.HandleFunc("/rest/api/2/issue/10002/watchers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		testRequestURL(t, r, "/rest/api/2/issue/10002/watchers?username=fred")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := testClient.Issue.RemoveWatcherUser(context.Background(), "10002", &User{Name: "fred"}); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestIssueService_EnsureWatchers(t *testing.T) {
+	setup()
+	defer teardown()
+	handleServerInfo(t, DeploymentTypeServer)
+
+	var added, removed []string
+	testMux.HandleFunc("/rest/api/2/issue/TEST-1/watchers", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"watchers":[{"name":"alice"},{"name":"mallory"}]}`)
+		case http.MethodPost:
+			var name string
+			_ = json.NewDecoder(r.Body).Decode(&name)
+			added = append(added, name)
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			removed = append(removed, r.URL.Query().Get("username"))
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	changes, err := testClient.Issue.EnsureWatchers(context.Background(), []string{"TEST-1"}, []User{{Name: "alice"}, {Name: "bob"}}, true)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if fmt.Sprint(added) != "[bob]" || fmt.Sprint(removed) != "[mallory]" {
+		t.Errorf("Expected bob to be added and mallory to be removed, got %v and %v", added, removed)
+	}
+	if len(changes) != 1 || len(changes[0].Added) != 1 || len(changes[0].Removed) != 1 {
+		t.Errorf("Unexpected changes %+v", changes)
+	}
+}
+
+func TestIssueService_Votes(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/10002/votes", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"self":"https://jira.example.com/rest/api/2/issue/10002/votes","votes":2,"hasVoted":true,"voters":[{"name":"fred"},{"name":"alice"}]}`)
+		case http.MethodPost, http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	votes, _, err := testClient.Issue.GetVotes(context.Background(), "10002")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if votes.Votes != 2 || !votes.HasVoted || len(votes.Voters) != 2 {
+		t.Errorf("Unexpected votes %+v", votes)
+	}
+	if _, err := testClient.Issue.AddVote(context.Background(), "10002"); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if _, err := testClient.Issue.RemoveVote(context.Background(), "10002"); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
@@ -0,0 +1,132 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// maxWorklogsPerList is the maximum number of worklog IDs accepted by the worklog list endpoint
+const maxWorklogsPerList = 1000
+
+// WorklogChange is an entry of the updated or deleted worklogs feed.
+type WorklogChange struct {
+	WorklogID int `json:"worklogId" structs:"worklogId"`
+	// UpdatedTime is the time of the change in milliseconds since the Unix epoch.
+	UpdatedTime int64            `json:"updatedTime" structs:"updatedTime"`
+	Properties  []EntityProperty `json:"properties,omitempty" structs:"properties,omitempty"`
+}
+
+// WorklogChangeList is a page of the updated or deleted worklogs feed.
+// If LastPage is false, the next page starts at Until.
+type WorklogChangeList struct {
+	Values []WorklogChange `json:"values" structs:"values"`
+	// Since and Until are in milliseconds since the Unix epoch.
+	Since    int64  `json:"since" structs:"since"`
+	Until    int64  `json:"until" structs:"until"`
+	Self     string `json:"self,omitempty" structs:"self,omitempty"`
+	NextPage string `json:"nextPage,omitempty" structs:"nextPage,omitempty"`
+	LastPage bool   `json:"lastPage" structs:"lastPage"`
+}
+
+// UntilTime returns Until as time.Time.
+func (l *WorklogChangeList) UntilTime() time.Time {
+	return time.Unix(0, l.Until*int64(time.Millisecond))
+}
+
+// GetUpdatedWorklogs returns a page of the IDs of the worklogs created or updated since the given time.
+// The feed is ordered by update time and returns at most 1000 worklogs per page.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/worklog-getIdsOfWorklogsModifiedSince
+func (s *IssueService) GetUpdatedWorklogs(ctx context.Context, since time.Time) (*WorklogChangeList, *Response, error) {
+	return s.getWorklogChanges(ctx, "rest/api/2/worklog/updated", since)
+}
+
+// GetDeletedWorklogs returns a page of the IDs of the worklogs deleted since the given time.
+// The feed is ordered by deletion time and returns at most 1000 worklogs per page.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/worklog-getIdsOfWorklogsDeletedSince
+func (s *IssueService) GetDeletedWorklogs(ctx context.Context, since time.Time) (*WorklogChangeList, *Response, error) {
+	return s.getWorklogChanges(ctx, "rest/api/2/worklog/deleted", since)
+}
+
+func (s *IssueService) getWorklogChanges(ctx context.Context, endpoint string, since time.Time) (*WorklogChangeList, *Response, error) {
+	apiEndpoint := fmt.Sprintf("%s?since=%d", endpoint, since.UnixNano()/int64(time.Millisecond))
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(WorklogChangeList)
+	resp, err := s.client.Do(req, list)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return list, resp, nil
+}
+
+// GetWorklogsByIDs returns the worklog records with the given IDs.
+// At most 1000 IDs can be requested at once.
+//
+// Jira API docs: https://docs.atlassian.com/software/jira/docs/api/REST/8.13.0/#api/2/worklog-getWorklogsForIds
+func (s *IssueService) GetWorklogsByIDs(ctx context.Context, ids []int) ([]WorklogRecord, *Response, error) {
+	apiEndpoint := "rest/api/2/worklog/list"
+	payload := struct {
+		IDs []int `json:"ids"`
+	}{IDs: ids}
+	req, err := s.client.NewRequest(ctx, http.MethodPost, apiEndpoint, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var records []WorklogRecord
+	resp, err := s.client.Do(req, &records)
+	if err != nil {
+		jerr := NewJiraError(resp, err)
+		return nil, resp, jerr
+	}
+
+	return records, resp, nil
+}
+
+// UpdatedWorklogsSince follows the updated worklogs feed from the given time to its last page
+// and calls f for every updated worklog record, fetching the records in batches.
+// It returns the time to pass as since on the next call to receive only later updates.
+func (s *IssueService) UpdatedWorklogsSince(ctx context.Context, since time.Time, f func(WorklogRecord) error) (time.Time, error) {
+	for {
+		changes, _, err := s.GetUpdatedWorklogs(ctx, since)
+		if err != nil {
+			return since, err
+		}
+
+		ids := make([]int, 0, len(changes.Values))
+		for _, change := range changes.Values {
+			ids = append(ids, change.WorklogID)
+		}
+		for start := 0; start < len(ids); start += maxWorklogsPerList {
+			end := start + maxWorklogsPerList
+			if end > len(ids) {
+				end = len(ids)
+			}
+			records, _, err := s.GetWorklogsByIDs(ctx, ids[start:end])
+			if err != nil {
+				return since, err
+			}
+			for _, record := range records {
+				if err := f(record); err != nil {
+					return since, err
+				}
+			}
+		}
+
+		if changes.Until > 0 {
+			since = changes.UntilTime()
+		}
+		if changes.LastPage || len(changes.Values) == 0 {
+			return since, nil
+		}
+	}
+}
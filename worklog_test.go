@@ -0,0 +1,95 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIssueService_GetUpdatedWorklogs(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/worklog/updated", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testRequestURL(t, r, "/rest/api/2/worklog/updated?since=1438013671562")
+
+		fmt.Fprint(w, `{"values":[{"worklogId":103,"updatedTime":1438013671562}],"since":1438013671562,"until":1438013693136,"lastPage":true}`)
+	})
+
+	changes, _, err := testClient.Issue.GetUpdatedWorklogs(context.Background(), time.Unix(0, 1438013671562*int64(time.Millisecond)))
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(changes.Values) != 1 || changes.Values[0].WorklogID != 103 || !changes.LastPage {
+		t.Errorf("Unexpected changes %+v", changes)
+	}
+	if changes.UntilTime().UnixNano()/int64(time.Millisecond) != 1438013693136 {
+		t.Errorf("Unexpected until time %v", changes.UntilTime())
+	}
+}
+
+func TestIssueService_GetDeletedWorklogs(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/worklog/deleted", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testRequestURL(t, r, "/rest/api/2/worklog/deleted?since=0")
+
+		fmt.Fprint(w, `{"values":[{"worklogId":104,"updatedTime":1438013671562}],"since":0,"until":1438013671562,"lastPage":true}`)
+	})
+
+	changes, _, err := testClient.Issue.GetDeletedWorklogs(context.Background(), time.Unix(0, 0))
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(changes.Values) != 1 || changes.Values[0].WorklogID != 104 {
+		t.Errorf("Unexpected changes %+v", changes)
+	}
+}
+
+func TestIssueService_UpdatedWorklogsSince(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/worklog/updated", func(w http.ResponseWriter, r *http.Request) {
+		switch since := r.URL.Query().Get("since"); since {
+		case "1000":
+			fmt.Fprint(w, `{"values":[{"worklogId":1},{"worklogId":2}],"since":1000,"until":2000,"lastPage":false}`)
+		case "2000":
+			fmt.Fprint(w, `{"values":[{"worklogId":3}],"since":2000,"until":3000,"lastPage":true}`)
+		default:
+			t.Errorf("Unexpected since %q", since)
+		}
+	})
+	testMux.HandleFunc("/rest/api/2/worklog/list", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		var payload struct {
+			IDs []int `json:"ids"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatal(err)
+		}
+		var records []WorklogRecord
+		for _, id := range payload.IDs {
+			records = append(records, WorklogRecord{ID: fmt.Sprint(id), IssueID: "10000"})
+		}
+		_ = json.NewEncoder(w).Encode(records)
+	})
+
+	var ids []string
+	next, err := testClient.Issue.UpdatedWorklogsSince(context.Background(), time.Unix(1, 0), func(record WorklogRecord) error {
+		ids = append(ids, record.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if fmt.Sprint(ids) != "[1 2 3]" {
+		t.Errorf("Unexpected worklogs %v", ids)
+	}
+	if !next.Equal(time.Unix(3, 0)) {
+		t.Errorf("Expected the next since to be the until of the last page, got %v", next)
+	}
+}